@@ -0,0 +1,950 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// conformanceScenario is an operation run against both the helper Runner and the RPC Client.
+// The operation returns a plain representation of the outcome so that both results can be diffed.
+type conformanceScenario struct {
+	Name    string
+	Files   map[string]string
+	Unknown []string
+	Env     map[string]string
+	Run     func(runner tflint.Runner) (interface{}, error)
+}
+
+// Test_Conformance runs the same scenarios against the helper Runner and the Client connected to devhost,
+// which is backed by the helper Runner, and diffs outcomes. The helper Runner is used in plugin tests,
+// so any difference (e.g. error types, ordering, unknown handling) makes plugin tests untrustworthy.
+func Test_Conformance(t *testing.T) {
+	src := `
+terraform {
+  required_version = ">= 0.12"
+
+  required_providers {
+    aws = "~> 2.0"
+  }
+
+  backend "s3" {
+    bucket = "tfstate"
+  }
+}
+
+variable "type" {
+  default = "t2.micro"
+}
+
+variable "computed" {}
+
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}
+
+locals {
+  name = "web"
+  env  = var.type
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "2.44.0"
+  cidr    = "10.0.0.0/16"
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+
+output "instance_type" {
+  value     = aws_instance.web.instance_type
+  sensitive = true
+}
+
+resource "aws_instance" "web" {
+  instance_type = var.type
+  ami           = var.computed
+  count         = 2
+  tags          = merge({ Name = "web" }, { Env = lower("PROD") })
+
+  ebs_block_device {
+    volume_size = 10
+  }
+}`
+
+	cases := []conformanceScenario{
+		{
+			Name:  "WalkResourceAttributes",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+					ret = append(ret, attribute.Name, attribute.Range.String(), attribute.Expr.Range().String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkResourceAttributesWithFields",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributesWithFields("aws_instance", "instance_type", tflint.AttributeRange, func(attribute *hcl.Attribute) error {
+					ret = append(ret, attribute.Name, attribute.Range.String(), attribute.NameRange.String(), fmt.Sprint(attribute.Expr == nil))
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "WalkResourceAttributes with wildcard",
+			Files: map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  tags = { Name = "web" }
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = { Name = "logs" }
+}
+
+resource "google_compute_instance" "web" {
+  tags = ["web"]
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_*", "tags", func(attribute *hcl.Attribute) error {
+					ret = append(ret, attribute.Range.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "WalkResourceBlocks with name",
+			Files: map[string]string{"main.tf": src + `
+
+resource "aws_instance" "db" {
+  ebs_block_device {
+    volume_size = 20
+  }
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceBlocks("aws_instance.db", "ebs_block_device", func(block *hcl.Block) error {
+					ret = append(ret, block.DefRange.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkResourceAttributeValues",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				for _, name := range []string{"instance_type", "ami"} {
+					err := runner.WalkResourceAttributeValues("aws_instance", name, func(attribute *hcl.Attribute, val cty.Value) error {
+						ret = append(ret, attribute.Name, val.GoString())
+						return nil
+					})
+					if err != nil {
+						return ret, err
+					}
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name: "GetDuplicateValues",
+			Files: map[string]string{"main.tf": `
+variable "cidr" {
+  default = "10.0.0.0/16"
+}
+
+resource "aws_vpc" "a" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_vpc" "b" {
+  cidr_block = "10.1.0.0/16"
+}
+
+resource "aws_vpc" "c" {
+  cidr_block = var.cidr
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				groups, err := runner.GetDuplicateValues("aws_vpc", "cidr_block")
+				for _, group := range groups {
+					ret = append(ret, group.Val.GoString())
+					for _, rng := range group.Ranges {
+						ret = append(ret, rng.String())
+					}
+				}
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkExpressions",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkExpressions(func(expr hcl.Expression) error {
+					ret = append(ret, fmt.Sprintf("%T", expr), expr.Range().String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "WalkResourceProvisioners",
+			Files: map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  connection {
+    host = self.public_ip
+  }
+
+  provisioner "local-exec" {
+    command = "echo ${self.private_ip}"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["puppet apply"]
+
+    connection {
+      host = self.private_ip
+    }
+  }
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceProvisioners("aws_instance", func(provisioner *tflint.Provisioner) error {
+					ret = append(ret, provisioner.Type, provisioner.Ranges.DefRange.String(), provisioner.Connection.Attributes["host"].Range.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "LookupEnv",
+			Files: map[string]string{"main.tf": src},
+			Env:   map[string]string{"AWS_REGION": "us-east-1"},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				for _, name := range []string{"AWS_REGION", "HOME"} {
+					value, exists, err := runner.LookupEnv(name)
+					if err != nil {
+						return ret, err
+					}
+					ret = append(ret, fmt.Sprintf("%s=%s (%t)", name, value, exists))
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name: "WalkExpandedResourceBlocks",
+			Files: map[string]string{"main.tf": `
+variable "sizes" {
+  default = [20, 30]
+}
+
+resource "aws_instance" "web" {
+  ebs_block_device {
+    volume_size = 10
+  }
+
+  dynamic "ebs_block_device" {
+    for_each = var.sizes
+
+    content {
+      volume_size = ebs_block_device.value
+    }
+  }
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkExpandedResourceBlocks("aws_instance", "ebs_block_device", func(block *tflint.ExpandedBlock) error {
+					attribute := block.Attributes["volume_size"]
+					ret = append(ret, block.DefRange.String(), attribute.Attribute.Range.String(), attribute.Val.GoString())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkDataSourceAttributes",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkDataSourceAttributes("aws_ami", "most_recent", func(attribute *hcl.Attribute) error {
+					ret = append(ret, attribute.Name, attribute.Range.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "EvaluateExpr",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_instance", "count", func(attribute *hcl.Attribute) error {
+					var val string
+					if err := runner.EvaluateExpr(attribute.Expr, &val); err != nil {
+						return err
+					}
+					ret = append(ret, val)
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:    "EvaluateExpr unknown",
+			Files:   map[string]string{"main.tf": src},
+			Unknown: []string{"type"},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				return nil, runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+					var val string
+					return runner.EvaluateExpr(attribute.Expr, &val)
+				})
+			},
+		},
+		{
+			Name:    "EnsureNoError",
+			Files:   map[string]string{"main.tf": src},
+			Unknown: []string{"type"},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+					var val string
+					err := runner.EvaluateExpr(attribute.Expr, &val)
+					return runner.EnsureNoError(err, func() error {
+						ret = append(ret, val)
+						return nil
+					})
+				})
+				if err != nil {
+					return ret, err
+				}
+				err = runner.WalkResourceAttributes("aws_instance", "tags", func(attribute *hcl.Attribute) error {
+					var val int
+					err := runner.EvaluateExpr(attribute.Expr, &val)
+					return runner.EnsureNoError(err, func() error {
+						ret = append(ret, fmt.Sprint(val))
+						return nil
+					})
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "EvaluateExpr with functions and unevaluable references",
+			Files: map[string]string{"main.tf": src + `
+
+resource "aws_instance" "db" {
+  instance_type = upper(var.type)
+  ami           = data.aws_ami.ubuntu.id
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				for _, name := range []string{"instance_type", "ami"} {
+					err := runner.WalkResourceAttributes("aws_instance.db", name, func(attribute *hcl.Attribute) error {
+						var val string
+						err := runner.EvaluateExpr(attribute.Expr, &val)
+						ret = append(ret, val, describeError(err))
+						return nil
+					})
+					if err != nil {
+						return ret, err
+					}
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name: "IsNullExpr",
+			Files: map[string]string{"main.tf": `
+variable "computed" {}
+
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+  ami           = null
+  user_data     = var.computed
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				for _, name := range []string{"instance_type", "ami", "user_data"} {
+					err := runner.WalkResourceAttributes("aws_instance", name, func(attribute *hcl.Attribute) error {
+						null, err := runner.IsNullExpr(attribute.Expr)
+						ret = append(ret, fmt.Sprintf("%s: %t", name, null))
+						return err
+					})
+					if err != nil {
+						return ret, err
+					}
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name: "EvaluateExpr into maps, slices and structs",
+			Files: map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  tags            = { Name = "web", Env = "prod" }
+  security_groups = ["default", "web"]
+
+  root_block_device = { volume_size = 16, encrypted = true }
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				var tags map[string]string
+				var securityGroups []string
+				var device struct {
+					VolumeSize int  `cty:"volume_size"`
+					Encrypted  bool `cty:"encrypted"`
+				}
+				ret := []string{}
+				for name, val := range map[string]interface{}{"tags": &tags, "security_groups": &securityGroups, "root_block_device": &device} {
+					err := runner.WalkResourceAttributes("aws_instance", name, func(attribute *hcl.Attribute) error {
+						return runner.EvaluateExpr(attribute.Expr, val)
+					})
+					if err != nil {
+						return ret, err
+					}
+				}
+				ret = append(ret, fmt.Sprintf("%v", tags), fmt.Sprintf("%v", securityGroups), fmt.Sprintf("%+v", device))
+				return ret, nil
+			},
+		},
+		{
+			Name:    "EvaluateExpr unknown into cty.Value",
+			Files:   map[string]string{"main.tf": src},
+			Unknown: []string{"type"},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+					var val cty.Value
+					if err := runner.EvaluateExpr(attribute.Expr, &val); err != nil {
+						return err
+					}
+					ret = append(ret, val.GoString())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "ExprSource",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+					src, err := runner.ExprSource(attribute.Expr)
+					ret = append(ret, src)
+					return err
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "GetResourceAttributeNames",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				resources, err := runner.GetResourceAttributeNames("aws_instance")
+				return resources, err
+			},
+		},
+		{
+			Name:  "WalkResourceBlocks",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceBlocks("aws_instance", "ebs_block_device", func(block *hcl.Block) error {
+					ret = append(ret, block.Type, block.DefRange.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkLocals",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkLocals(func(name string, expr hcl.Expression) error {
+					var val string
+					if err := runner.EvaluateExpr(expr, &val); err != nil {
+						return err
+					}
+					ret = append(ret, name, expr.Range().String(), val)
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkVariables",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkVariables(func(variable *tflint.Variable) error {
+					ret = append(ret, variable.Name, variable.DeclRange.String(), variable.DescriptionText())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkOutputs",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkOutputs(func(output *tflint.Output) error {
+					ret = append(ret, output.Name, output.Value.Expr.Range().String(), fmt.Sprint(output.IsSensitive()))
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "WalkMovedBlocks and WalkImportBlocks",
+			Files: map[string]string{"main.tf": `
+moved {
+  from = aws_instance.old
+  to   = aws_instance.new
+}
+
+import {
+  for_each = var.ids
+  to       = aws_instance.imported[each.key]
+  id       = each.value
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkMovedBlocks(func(moved *tflint.Moved) error {
+					ret = append(ret, fmt.Sprintf("moved %s -> %s", moved.From.Expr.Range(), moved.To.Expr.Range()))
+					return nil
+				})
+				if err != nil {
+					return ret, err
+				}
+				err = runner.WalkImportBlocks(func(imp *tflint.Import) error {
+					ret = append(ret, fmt.Sprintf("import %s (for_each: %t, id: %q)", imp.To.Expr.Range(), imp.ForEach != nil, imp.IDText()))
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "WalkConditions",
+			Files: map[string]string{"main.tf": `
+check "health" {
+  assert {
+    condition     = data.http.health.status_code == 200
+    error_message = "The service is unhealthy."
+  }
+}
+
+resource "aws_instance" "web" {
+  lifecycle {
+    postcondition {
+      condition     = self.public_dns != ""
+      error_message = "The instance has no public DNS."
+    }
+  }
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkConditions(func(condition *tflint.Condition) error {
+					ret = append(ret, fmt.Sprintf("%s %s %T %q", condition.Kind, condition.Condition.Expr.Range(), condition.Condition.Expr, condition.ErrorMessageText()))
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkResources",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResources("aws_*", func(resource *tflint.Resource) error {
+					content, _, diags := resource.Body.PartialContent(&hcl.BodySchema{
+						Attributes: []hcl.AttributeSchema{{Name: "ami"}, {Name: "instance_type"}, {Name: "tags"}},
+					})
+					if diags.HasErrors() {
+						return diags
+					}
+					names := []string{}
+					for name, attribute := range content.Attributes {
+						names = append(names, fmt.Sprintf("%s@%s", name, attribute.Range))
+					}
+					sort.Strings(names)
+					ret = append(ret, fmt.Sprintf("%s %s: %s", strings.Join(resource.Labels, "."), resource.Ranges.Range(), strings.Join(names, ", ")))
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkTerraformSettings",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkTerraformSettings(func(settings *tflint.TerraformSettings) error {
+					ret = append(ret, settings.RequiredVersion.Range.String(), settings.RequiredProviders["aws"].Range.String(), settings.Backend.DefRange.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "Backend",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				backend, err := runner.Backend()
+				if err != nil || backend == nil {
+					return nil, err
+				}
+				return []string{backend.Type, backend.DeclRange.String(), backend.Attributes["bucket"].Range.String()}, nil
+			},
+		},
+		{
+			Name:  "WalkModuleCalls",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkModuleCalls(func(call *tflint.ModuleCall) error {
+					ret = append(ret, call.Name, call.Source.Range.String(), call.Version.Range.String(), call.Inputs["cidr"].Range.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "WalkProviderConfigs",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkProviderConfigs(func(provider *tflint.ProviderConfig) error {
+					ret = append(ret, provider.Key(), provider.Attributes["region"].Range.String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name: "GetProviderConfigValue null and unknown",
+			Files: map[string]string{"main.tf": `
+variable "access_key" {}
+
+provider "aws" {
+  profile    = null
+  access_key = var.access_key
+}`},
+			Unknown: []string{"access_key"},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				for _, name := range []string{"profile", "access_key"} {
+					var val cty.Value
+					exists, err := runner.GetProviderConfigValue("aws", name, &val)
+					if err != nil {
+						return ret, err
+					}
+					ret = append(ret, fmt.Sprintf("%t", exists), val.GoString())
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name:  "Count",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []int{}
+				for _, count := range []func() (int, error){
+					func() (int, error) { return runner.CountResources("aws_instance") },
+					func() (int, error) { return runner.CountBlocks("aws_instance", "ebs_block_device") },
+					func() (int, error) { return runner.CountBlocks("", "variable") },
+				} {
+					n, err := count()
+					if err != nil {
+						return ret, err
+					}
+					ret = append(ret, n)
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name:  "GetFunctionCalls",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				calls, err := runner.GetFunctionCalls()
+				return calls, err
+			},
+		},
+		{
+			Name:  "GetFiles",
+			Files: map[string]string{"main.tf": src, "prod.tfvars": `type = "t3.micro"`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				filenames, err := runner.GetFiles("*")
+				return filenames, err
+			},
+		},
+		{
+			Name:  "GetFiles malformed pattern",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				filenames, err := runner.GetFiles("[")
+				return filenames, err
+			},
+		},
+		{
+			Name:  "GetModuleContent",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				content, err := runner.GetModuleContent(&hcl.BodySchema{
+					Blocks: []hcl.BlockHeaderSchema{
+						{Type: "resource", LabelNames: []string{"type", "name"}},
+						{Type: "variable", LabelNames: []string{"name"}},
+					},
+				})
+				if err != nil {
+					return ret, err
+				}
+				for _, block := range content.Blocks {
+					inner, _, diags := block.Body.PartialContent(&hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "instance_type"}}})
+					if diags.HasErrors() {
+						return ret, diags
+					}
+					ret = append(ret, fmt.Sprintf("%s %s (%d attributes)", block.Type, strings.Join(block.Labels, "."), len(inner.Attributes)))
+				}
+				sort.Strings(ret)
+				return ret, nil
+			},
+		},
+		{
+			Name: "GetModuleContent with operators",
+			Files: map[string]string{"main.tf": `
+variable "size" {
+  default = 1
+}
+
+resource "aws_instance" "web" {
+  count = 1 + var.size
+
+  ebs_block_device {
+    volume_size = var.size * 10
+  }
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				content, err := runner.GetModuleContent(&hcl.BodySchema{
+					Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+				})
+				if err != nil {
+					return ret, err
+				}
+				for _, block := range content.Blocks {
+					inner, _, diags := block.Body.PartialContent(&hcl.BodySchema{
+						Attributes: []hcl.AttributeSchema{{Name: "count"}},
+						Blocks:     []hcl.BlockHeaderSchema{{Type: "ebs_block_device"}},
+					})
+					if diags.HasErrors() {
+						return ret, diags
+					}
+					var count int
+					if err := runner.EvaluateExpr(inner.Attributes["count"].Expr, &count); err != nil {
+						return ret, err
+					}
+					ret = append(ret, fmt.Sprintf("count = %d", count), inner.Attributes["count"].Expr.Range().String())
+					for _, device := range inner.Blocks {
+						attributes, diags := device.Body.JustAttributes()
+						if diags.HasErrors() {
+							return ret, diags
+						}
+						ret = append(ret, attributes["volume_size"].Expr.Range().String())
+					}
+				}
+				return ret, nil
+			},
+		},
+		{
+			Name: "WalkResourceAttributes with operators",
+			Files: map[string]string{"main.tf": `
+variable "size" {
+  default = 1
+}
+
+resource "aws_instance" "web" {
+  count = 1 + var.size
+}`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				err := runner.WalkResourceAttributes("aws_instance", "count", func(attribute *hcl.Attribute) error {
+					var count int
+					if err := runner.EvaluateExpr(attribute.Expr, &count); err != nil {
+						return err
+					}
+					ret = append(ret, fmt.Sprintf("count = %d", count), attribute.Expr.Range().String())
+					return nil
+				})
+				return ret, err
+			},
+		},
+		{
+			Name:  "Empty configuration",
+			Files: map[string]string{"prod.tfvars": `type = "t3.micro"`},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				ret := []string{}
+				empty, err := runner.IsEmpty()
+				if err != nil {
+					return ret, err
+				}
+				ret = append(ret, fmt.Sprintf("empty: %t", empty))
+				err = runner.WalkResourceAttributes("aws_*", "ami", func(attr *hcl.Attribute) error {
+					ret = append(ret, attr.Name)
+					return nil
+				})
+				if err != nil {
+					return ret, err
+				}
+				count, err := runner.CountResources("aws_instance")
+				if err != nil {
+					return ret, err
+				}
+				ret = append(ret, fmt.Sprintf("count: %d", count))
+				content, err := runner.GetModuleContent(&hcl.BodySchema{Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}}})
+				if err != nil {
+					return ret, err
+				}
+				ret = append(ret, fmt.Sprintf("blocks: %d", len(content.Blocks)))
+				return ret, nil
+			},
+		},
+		{
+			Name:  "ModulePath",
+			Files: map[string]string{"main.tf": src},
+			Run: func(runner tflint.Runner) (interface{}, error) {
+				path, err := runner.ModulePath()
+				return path.String(), err
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		local := helper.TestRunner(t, tc.Files)
+		local.UnknownVariables = tc.Unknown
+		local.Env = tc.Env
+		expected, expectedErr := tc.Run(local)
+
+		remote := helper.TestRunner(t, tc.Files)
+		remote.UnknownVariables = tc.Unknown
+		remote.Env = tc.Env
+		client := startConformanceServer(t, remote)
+		got, err := tc.Run(client)
+
+		if describeError(expectedErr) != describeError(err) {
+			t.Fatalf("Failed `%s` test: the helper returns `%s`, but the client returns `%s`", tc.Name, describeError(expectedErr), describeError(err))
+		}
+		if expectedErr == nil && !cmp.Equal(expected, got) {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, cmp.Diff(expected, got))
+		}
+	}
+}
+
+func Test_ModuleContent_json(t *testing.T) {
+	// The test Runner parses files in native syntax only
+	file, diags := json.Parse([]byte(`{"resource": {"aws_instance": {"web": {"instance_type": "t2.micro"}}}}`), "main.tf.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner := &helper.Runner{Files: map[string]*hcl.File{"main.tf.json": file}}
+	client := startConformanceServer(t, runner)
+
+	_, err := client.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if err == nil {
+		t.Fatal("Expected an error, but no error occurred")
+	}
+	expected := "Failed to send the `resource` block declared in main.tf.json:1,39-40: block bodies in JSON syntax are not supported by devhost"
+	if err.Error() != expected {
+		t.Fatalf("Expected `%s`, but got `%s`", expected, err)
+	}
+}
+
+func Test_WalkAttributeOrder_json(t *testing.T) {
+	// The test Runner parses files in native syntax only
+	file, diags := json.Parse([]byte(`{"resource": {"aws_instance": {"web": {"ami": "ami-1234", "ami": "ami-5678"}}}}`), "main.tf.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner := &helper.Runner{Files: map[string]*hcl.File{"main.tf.json": file}}
+
+	walk := func(runner tflint.Runner) []string {
+		ret := []string{}
+		err := runner.WalkAttributeOrder("aws_instance", func(order *tflint.AttributeOrder) error {
+			for _, attribute := range append(order.Attributes, order.Duplicates...) {
+				var val string
+				if err := runner.EvaluateExpr(attribute.Expr, &val); err != nil {
+					return err
+				}
+				ret = append(ret, attribute.Name, attribute.Range.String(), val)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+
+	expected := walk(runner)
+	got := walk(startConformanceServer(t, runner))
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+	if len(got) != 6 || got[5] != "ami-5678" {
+		t.Fatalf("Expected the duplicate to be walked, but got %v", got)
+	}
+}
+
+// describeError returns a representation of the error that is comparable across RPC.
+// Errors other than tflint.Error are converted into tflint.Error by devhost, so only messages are compared.
+func describeError(err error) string {
+	if err == nil {
+		return "no error"
+	}
+	if appErr, ok := err.(tflint.Error); ok && appErr.Code != tflint.EvaluationError {
+		return fmt.Sprintf("%s(%s): %s", appErr.Code, appErr.Level, appErr.Error())
+	}
+	return err.Error()
+}
+
+func startConformanceServer(t *testing.T, runner *helper.Runner) *tflint.Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("Plugin", &Server{runner: runner}); err != nil {
+		t.Fatal(err)
+	}
+
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+	t.Cleanup(func() { clientConn.Close() })
+
+	return tflint.NewClient(clientConn)
+}
@@ -0,0 +1,217 @@
+// Command devhost is a minimal host emulator for local rule development.
+// It loads Terraform configuration files from a directory, launches a locally built plugin
+// over the real plugin protocol, and prints emitted issues.
+// In query mode, it runs query rules and prints emitted records as JSON lines instead.
+//
+// Usage:
+//
+//	devhost -plugin ./tflint-ruleset-example [-offline] [-query] [-env AWS_REGION,TF_VAR_name] [-seed 42] [-now 2020-01-01T00:00:00Z] [dir]
+//	devhost -plugin ./tflint-ruleset-example -explain rule_name
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func main() {
+	pluginPath := flag.String("plugin", "", "path to the plugin binary")
+	offline := flag.Bool("offline", false, "run in offline mode")
+	query := flag.Bool("query", false, "run query rules and print emitted records")
+	explain := flag.String("explain", "", "print the documentation of the rule")
+	env := flag.String("env", "", "comma-separated list of environment variables visible to the plugin")
+	seed := flag.Int64("seed", 0, "seed of random numbers for rules (generated if 0)")
+	now := flag.String("now", "", "fixed time for rules in RFC 3339 (e.g. 2020-01-01T00:00:00Z)")
+	flag.Parse()
+
+	if *pluginPath == "" {
+		fmt.Fprintln(os.Stderr, "Usage: devhost -plugin <path> [dir]")
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if *explain != "" {
+		os.Exit(explainRule(*pluginPath, *explain))
+	}
+
+	var fixedTime time.Time
+	if *now != "" {
+		t, err := time.Parse(time.RFC3339, *now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid time: %s\n", err)
+			os.Exit(2)
+		}
+		fixedTime = t
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	log.Printf("[INFO] Seed: %d", *seed)
+
+	os.Exit(run(*pluginPath, dir, &tflint.Config{Rules: map[string]*tflint.RuleConfig{}, Offline: *offline, Query: *query}, allowedEnv(*env), fixedTime, *seed))
+}
+
+// allowedEnv returns the environment variables in the passed comma-separated list that are set
+func allowedEnv(list string) map[string]string {
+	env := map[string]string{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if value, exists := os.LookupEnv(name); exists {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+func launch(pluginPath string) (*plugin.Client, func(), error) {
+	client := plugin.NewClient(&plugin.ClientOpts{Cmd: exec.Command(pluginPath)})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, nil, fmt.Errorf("Failed to launch the plugin: %s", err)
+	}
+	raw, err := rpcClient.Dispense("ruleset")
+	if err != nil {
+		client.Kill()
+		return nil, nil, fmt.Errorf("Failed to dispense the ruleset: %s", err)
+	}
+	return raw.(*plugin.Client), client.Kill, nil
+}
+
+func explainRule(pluginPath, name string) int {
+	ruleset, kill, err := launch(pluginPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer kill()
+
+	doc, err := ruleset.RuleDocumentation(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get the documentation: %s\n", err)
+		return 1
+	}
+	if doc == "" {
+		fmt.Printf("`%s` rule has no documentation\n", name)
+		return 0
+	}
+	fmt.Println(doc)
+	return 0
+}
+
+func run(pluginPath, dir string, config *tflint.Config, env map[string]string, fixedTime time.Time, seed int64) int {
+	runner, err := helper.NewLocalRunner(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configurations: %s\n", err)
+		return 1
+	}
+	runner.Env = env
+	runner.FixedTime = fixedTime
+	runner.Seed = seed
+	if empty, _ := runner.IsEmpty(); empty {
+		log.Printf("[INFO] No Terraform files found in %s", dir)
+	}
+
+	ruleset, kill, err := launch(pluginPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer kill()
+
+	name, err := ruleset.RuleSetName()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get the ruleset name: %s\n", err)
+		return 1
+	}
+	version, err := ruleset.RuleSetVersion()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get the ruleset version: %s\n", err)
+		return 1
+	}
+	log.Printf("[INFO] Loaded ruleset: %s (%s)", name, version)
+
+	functions, err := ruleset.Functions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get custom functions: %s\n", err)
+		return 1
+	}
+	runner.Functions = functions
+
+	if err := ruleset.ApplyConfig(config); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to apply config: %s\n", err)
+		return 1
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to resolve the directory: %s\n", err)
+		return 1
+	}
+	server := &Server{runner: runner, root: root}
+	if err := ruleset.Check(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to check: %s\n", err)
+		return 1
+	}
+
+	if config.Query {
+		return printRecords(server.runner.Records)
+	}
+
+	for _, issue := range server.runner.Issues {
+		fmt.Printf(
+			"%s:%d:%d: %s - %s (%s)\n",
+			issue.Range.Filename,
+			issue.Range.Start.Line,
+			issue.Range.Start.Column,
+			issue.Rule.Severity(),
+			issue.Message,
+			issue.Rule.Name(),
+		)
+	}
+	if len(server.runner.Issues) > 0 {
+		return 3
+	}
+	return 0
+}
+
+// printRecords prints records as JSON lines
+func printRecords(records []*tflint.Record) int {
+	for _, record := range records {
+		value, err := ctyjson.Marshal(record.Value, record.Value.Type())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode the record of `%s` rule: %s\n", record.Rule, err)
+			return 1
+		}
+		line, err := json.Marshal(struct {
+			Rule     string          `json:"rule"`
+			Location string          `json:"location"`
+			Value    json.RawMessage `json:"value"`
+		}{record.Rule, record.Location.String(), value})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode the record of `%s` rule: %s\n", record.Rule, err)
+			return 1
+		}
+		fmt.Println(string(line))
+	}
+	return 0
+}
@@ -0,0 +1,714 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// Server is a pseudo host server that responds to queries from the plugin.
+// It is backed by the helper Runner, so the same restrictions as plugin tests apply.
+type Server struct {
+	runner *helper.Runner
+	root   string
+}
+
+var _ tflint.Server = (*Server)(nil)
+
+// Attributes returns attributes that match the conditions
+func (s *Server) Attributes(req *tflint.AttributesRequest, resp *tflint.AttributesResponse) error {
+	attributes := []*hcl.Attribute{}
+	err := s.runner.WalkResourceAttributesWithFields(req.Resource, req.AttributeName, req.Fields, func(attribute *hcl.Attribute) error {
+		if attribute.Expr != nil {
+			attribute = s.wireAttribute(attribute)
+		}
+		attributes = append(attributes, attribute)
+		return nil
+	})
+	*resp = tflint.AttributesResponse{Attributes: attributes, Err: wrapError(err)}
+	if req.CompactFilenames && err == nil {
+		// Encoding replaces filenames in place, so encode a copy to keep the runner's files intact.
+		var copied []*hcl.Attribute
+		if err := deepCopy(attributes, &copied); err != nil {
+			return err
+		}
+		resp.Filenames.Encode(&copied)
+		resp.Attributes = copied
+	}
+	return nil
+}
+
+// Prefetch returns attributes and blocks for multiple requests at once
+func (s *Server) Prefetch(req *tflint.PrefetchRequest, resp *tflint.PrefetchResponse) error {
+	ret := tflint.PrefetchResponse{}
+	for _, attributes := range req.Attributes {
+		var response tflint.AttributesResponse
+		if err := s.Attributes(&attributes, &response); err != nil {
+			return err
+		}
+		ret.Attributes = append(ret.Attributes, &response)
+	}
+	for _, blocks := range req.Blocks {
+		var response tflint.BlocksResponse
+		if err := s.Blocks(&blocks, &response); err != nil {
+			return err
+		}
+		ret.Blocks = append(ret.Blocks, &response)
+	}
+	*resp = ret
+	return nil
+}
+
+// AttributeValues returns attributes that match the conditions with their values
+func (s *Server) AttributeValues(req *tflint.AttributesRequest, resp *tflint.AttributeValuesResponse) error {
+	attributes := []*tflint.EvaluatedAttribute{}
+	err := s.runner.WalkResourceAttributeValues(req.Resource, req.AttributeName, func(attribute *hcl.Attribute, val cty.Value) error {
+		evaluated := &tflint.EvaluatedAttribute{Attribute: s.wireAttribute(attribute)}
+		if val.IsWhollyKnown() && !val.Type().HasDynamicTypes() {
+			evaluated.Val = &val
+			evaluated.Sensitive, _ = s.runner.IsSensitive(attribute.Expr)
+		}
+		attributes = append(attributes, evaluated)
+		return nil
+	})
+	*resp = tflint.AttributeValuesResponse{Attributes: attributes, Err: wrapError(err)}
+	return nil
+}
+
+// DuplicateValues returns groups of attributes that have the same value
+func (s *Server) DuplicateValues(req *tflint.AttributesRequest, resp *tflint.DuplicateValuesResponse) error {
+	groups, err := s.runner.GetDuplicateValues(req.Resource, req.AttributeName)
+	*resp = tflint.DuplicateValuesResponse{Groups: groups, Err: wrapError(err)}
+	return nil
+}
+
+// ExpandedBlocks returns nested blocks of resources after expanding dynamic blocks
+func (s *Server) ExpandedBlocks(req *tflint.BlocksRequest, resp *tflint.ExpandedBlocksResponse) error {
+	blocks := []*tflint.ExpandedBlock{}
+	err := s.runner.WalkExpandedResourceBlocks(req.Resource, req.BlockType, func(block *tflint.ExpandedBlock) error {
+		for _, attribute := range block.Attributes {
+			attribute.Attribute = s.wireAttribute(attribute.Attribute)
+		}
+		blocks = append(blocks, block)
+		return nil
+	})
+	*resp = tflint.ExpandedBlocksResponse{Blocks: blocks, Err: wrapError(err)}
+	return nil
+}
+
+// DataSourceAttributes returns attributes of data sources that match the conditions
+func (s *Server) DataSourceAttributes(req *tflint.DataSourceAttributesRequest, resp *tflint.DataSourceAttributesResponse) error {
+	attributes := []*hcl.Attribute{}
+	err := s.runner.WalkDataSourceAttributes(req.DataSource, req.AttributeName, func(attribute *hcl.Attribute) error {
+		attributes = append(attributes, s.wireAttribute(attribute))
+		return nil
+	})
+	*resp = tflint.DataSourceAttributesResponse{Attributes: attributes, Err: wrapError(err)}
+	return nil
+}
+
+// wireAttribute replaces expressions that cannot be sent via RPC (e.g. JSON syntax, operators) with the wire representation
+func (s *Server) wireAttribute(attribute *hcl.Attribute) *hcl.Attribute {
+	if expr, ok := attribute.Expr.(hclsyntax.Expression); ok && !unsendable(expr) {
+		return attribute
+	}
+	if _, ok := attribute.Expr.(*tflint.WireExpr); ok {
+		return attribute
+	}
+	file, ok := s.runner.Files[attribute.Range.Filename]
+	if !ok {
+		file, ok = s.runner.VariableFiles[attribute.Range.Filename]
+	}
+	for _, child := range s.runner.ChildModules {
+		if !ok {
+			file, ok = child.Files[attribute.Range.Filename]
+		}
+	}
+	if !ok {
+		return attribute
+	}
+
+	wired := *attribute
+	wired.Expr = tflint.NewWireExpr(attribute.Expr, file.Bytes)
+	return &wired
+}
+
+// unsendable returns true if the expression cannot be encoded by gob.
+// Operators hold functions, and literals of dynamic types (e.g. `null`) have no concrete type,
+// so such expressions are sent as a wire representation.
+func unsendable(expr hclsyntax.Expression) bool {
+	ret := false
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch node := node.(type) {
+		case *hclsyntax.BinaryOpExpr, *hclsyntax.UnaryOpExpr:
+			ret = true
+		case *hclsyntax.LiteralValueExpr:
+			if node.Val.Type().HasDynamicTypes() {
+				ret = true
+			}
+		}
+		return nil
+	})
+	return ret
+}
+
+// Blocks returns nested blocks that match the conditions
+// Blocks in JSON syntax cannot be sent via RPC, so they are omitted.
+func (s *Server) Blocks(req *tflint.BlocksRequest, resp *tflint.BlocksResponse) error {
+	blocks := []*hcl.Block{}
+	err := s.runner.WalkResourceBlocks(req.Resource, req.BlockType, func(block *hcl.Block) error {
+		if _, ok := block.Body.(*hclsyntax.Body); ok {
+			blocks = append(blocks, block)
+		}
+		return nil
+	})
+	*resp = tflint.BlocksResponse{Blocks: blocks, Err: wrapError(err)}
+	if req.CompactFilenames && err == nil {
+		// Encoding replaces filenames in place, so encode a copy to keep the runner's files intact.
+		var copied []*hcl.Block
+		if err := deepCopy(blocks, &copied); err != nil {
+			return err
+		}
+		resp.Filenames.Encode(&copied)
+		resp.Blocks = copied
+	}
+	return nil
+}
+
+// Count returns the number of resources or blocks that match the conditions
+func (s *Server) Count(req *tflint.CountRequest, resp *tflint.CountResponse) error {
+	var count int
+	var err error
+	if req.BlockType == "" {
+		count, err = s.runner.CountResources(req.Resource)
+	} else {
+		count, err = s.runner.CountBlocks(req.Resource, req.BlockType)
+	}
+	*resp = tflint.CountResponse{Count: count, Err: wrapError(err)}
+	return nil
+}
+
+// AttributeOrder returns attributes of resources in declaration order
+func (s *Server) AttributeOrder(req *tflint.AttributeOrderRequest, resp *tflint.AttributeOrderResponse) error {
+	orders := []*tflint.AttributeOrder{}
+	err := s.runner.WalkAttributeOrder(req.Resource, func(order *tflint.AttributeOrder) error {
+		for _, attributes := range [][]*hcl.Attribute{order.Attributes, order.Duplicates} {
+			for i, attribute := range attributes {
+				attributes[i] = s.wireAttribute(attribute)
+			}
+		}
+		orders = append(orders, order)
+		return nil
+	})
+	*resp = tflint.AttributeOrderResponse{Orders: orders, Err: wrapError(err)}
+	return nil
+}
+
+// ModuleContent returns the content of the module that matches the schema with the source of each block
+// Block bodies in JSON syntax cannot be parsed from the source, and cannot be converted without the schema of nested blocks,
+// so an error is returned instead of omitting them. Otherwise rules would silently see fewer blocks than the helper Runner.
+func (s *Server) ModuleContent(req *tflint.ModuleContentRequest, resp *tflint.ModuleContentResponse) error {
+	content, err := s.runner.GetModuleContent(req.Schema)
+	if err != nil {
+		*resp = tflint.ModuleContentResponse{Err: wrapError(err)}
+		return nil
+	}
+
+	wired := &hcl.BodyContent{Attributes: hcl.Attributes{}, Blocks: hcl.Blocks{}, MissingItemRange: content.MissingItemRange}
+	for name, attribute := range content.Attributes {
+		wired.Attributes[name] = s.wireAttribute(attribute)
+	}
+	sources := [][]byte{}
+	for _, block := range content.Blocks {
+		if _, ok := block.Body.(*hclsyntax.Body); !ok {
+			err := fmt.Errorf("Failed to send the `%s` block declared in %s: block bodies in JSON syntax are not supported by devhost", block.Type, block.DefRange)
+			*resp = tflint.ModuleContentResponse{Err: wrapError(err)}
+			return nil
+		}
+		rng := tflint.NewBlockRanges(block).Range()
+		sources = append(sources, s.runner.Files[rng.Filename].Bytes[rng.Start.Byte:rng.End.Byte])
+		wired.Blocks = append(wired.Blocks, &hcl.Block{
+			Type:        block.Type,
+			Labels:      block.Labels,
+			DefRange:    block.DefRange,
+			TypeRange:   block.TypeRange,
+			LabelRanges: block.LabelRanges,
+		})
+	}
+	*resp = tflint.ModuleContentResponse{Content: wired, Sources: sources}
+	return nil
+}
+
+// ResourceAttributeNames returns attribute names present in each resource
+func (s *Server) ResourceAttributeNames(req *tflint.ResourceAttributeNamesRequest, resp *tflint.ResourceAttributeNamesResponse) error {
+	resources, err := s.runner.GetResourceAttributeNames(req.Resource)
+	*resp = tflint.ResourceAttributeNamesResponse{Resources: resources, Err: wrapError(err)}
+	return nil
+}
+
+// Resources returns resources that match the conditions with the source of each block
+// Resources in JSON syntax cannot be parsed from the source, so they are omitted.
+func (s *Server) Resources(req *tflint.ResourcesRequest, resp *tflint.ResourcesResponse) error {
+	resources := []*tflint.Resource{}
+	sources := [][]byte{}
+	err := s.runner.WalkResources(req.Resource, func(resource *tflint.Resource) error {
+		if _, ok := resource.Body.(*hclsyntax.Body); !ok {
+			return nil
+		}
+		rng := resource.Ranges.Range()
+		sources = append(sources, s.runner.Files[rng.Filename].Bytes[rng.Start.Byte:rng.End.Byte])
+		resource.Body = nil
+		resources = append(resources, resource)
+		return nil
+	})
+	*resp = tflint.ResourcesResponse{Resources: resources, Sources: sources, Err: wrapError(err)}
+	return nil
+}
+
+// MetaArguments returns meta-arguments of resources
+// Lifecycle blocks in JSON syntax cannot be sent via RPC, so they are omitted.
+func (s *Server) MetaArguments(req *tflint.MetaArgumentsRequest, resp *tflint.MetaArgumentsResponse) error {
+	resources := []*tflint.MetaArguments{}
+	err := s.runner.WalkResourceMetaArguments(req.Resource, func(meta *tflint.MetaArguments) error {
+		for _, attribute := range []**hcl.Attribute{&meta.Count, &meta.ForEach, &meta.Provider, &meta.DependsOn} {
+			if *attribute != nil {
+				*attribute = s.wireAttribute(*attribute)
+			}
+		}
+		if meta.Lifecycle != nil {
+			if _, ok := meta.Lifecycle.Body.(*hclsyntax.Body); !ok {
+				meta.Lifecycle = nil
+			}
+		}
+		resources = append(resources, meta)
+		return nil
+	})
+	*resp = tflint.MetaArgumentsResponse{Resources: resources, Err: wrapError(err)}
+	return nil
+}
+
+// Provisioners returns provisioners of resources that match the conditions
+func (s *Server) Provisioners(req *tflint.ProvisionersRequest, resp *tflint.ProvisionersResponse) error {
+	provisioners := []*tflint.Provisioner{}
+	err := s.runner.WalkResourceProvisioners(req.Resource, func(provisioner *tflint.Provisioner) error {
+		for name, attribute := range provisioner.Attributes {
+			provisioner.Attributes[name] = s.wireAttribute(attribute)
+		}
+		if provisioner.Connection != nil {
+			// The connection may be shared by provisioners, so copy it before replacing attributes.
+			connection := *provisioner.Connection
+			connection.Attributes = map[string]*hcl.Attribute{}
+			for name, attribute := range provisioner.Connection.Attributes {
+				connection.Attributes[name] = s.wireAttribute(attribute)
+			}
+			provisioner.Connection = &connection
+		}
+		provisioners = append(provisioners, provisioner)
+		return nil
+	})
+	*resp = tflint.ProvisionersResponse{Provisioners: provisioners, Err: wrapError(err)}
+	return nil
+}
+
+// Locals returns local values in the module
+func (s *Server) Locals(args interface{}, resp *tflint.LocalsResponse) error {
+	locals, err := s.runner.GetLocals()
+	for i, local := range locals {
+		locals[i] = s.wireAttribute(local)
+	}
+	*resp = tflint.LocalsResponse{Locals: locals, Err: wrapError(err)}
+	return nil
+}
+
+// Variables returns variable declarations in the module
+func (s *Server) Variables(args interface{}, resp *tflint.VariablesResponse) error {
+	variables := []*tflint.Variable{}
+	err := s.runner.WalkVariables(func(variable *tflint.Variable) error {
+		for _, attribute := range []**hcl.Attribute{&variable.Type, &variable.Default, &variable.Description, &variable.Sensitive} {
+			if *attribute != nil {
+				*attribute = s.wireAttribute(*attribute)
+			}
+		}
+		variables = append(variables, variable)
+		return nil
+	})
+	*resp = tflint.VariablesResponse{Variables: variables, Err: wrapError(err)}
+	return nil
+}
+
+// Outputs returns output declarations in the module
+func (s *Server) Outputs(args interface{}, resp *tflint.OutputsResponse) error {
+	outputs := []*tflint.Output{}
+	err := s.runner.WalkOutputs(func(output *tflint.Output) error {
+		for _, attribute := range []**hcl.Attribute{&output.Value, &output.Description, &output.Sensitive, &output.DependsOn} {
+			if *attribute != nil {
+				*attribute = s.wireAttribute(*attribute)
+			}
+		}
+		outputs = append(outputs, output)
+		return nil
+	})
+	*resp = tflint.OutputsResponse{Outputs: outputs, Err: wrapError(err)}
+	return nil
+}
+
+// VariableFlows returns the flows of the arguments of the module call into the child module
+func (s *Server) VariableFlows(req *tflint.VariableFlowsRequest, resp *tflint.VariableFlowsResponse) error {
+	flows, err := s.runner.GetVariableFlows(req.Module)
+	for _, flow := range flows {
+		flow.Argument = s.wireAttribute(flow.Argument)
+		if flow.Variable != nil {
+			for _, attribute := range []**hcl.Attribute{&flow.Variable.Type, &flow.Variable.Default, &flow.Variable.Description, &flow.Variable.Sensitive} {
+				if *attribute != nil {
+					*attribute = s.wireAttribute(*attribute)
+				}
+			}
+		}
+		for _, output := range flow.Outputs {
+			for _, attribute := range []**hcl.Attribute{&output.Value, &output.Description, &output.Sensitive, &output.DependsOn} {
+				if *attribute != nil {
+					*attribute = s.wireAttribute(*attribute)
+				}
+			}
+		}
+	}
+	*resp = tflint.VariableFlowsResponse{Flows: flows, Err: wrapError(err)}
+	return nil
+}
+
+// MovedBlocks returns moved blocks in the module
+func (s *Server) MovedBlocks(args interface{}, resp *tflint.MovedBlocksResponse) error {
+	moved := []*tflint.Moved{}
+	err := s.runner.WalkMovedBlocks(func(m *tflint.Moved) error {
+		for _, attribute := range []**hcl.Attribute{&m.From, &m.To} {
+			if *attribute != nil {
+				*attribute = s.wireAttribute(*attribute)
+			}
+		}
+		moved = append(moved, m)
+		return nil
+	})
+	*resp = tflint.MovedBlocksResponse{Moved: moved, Err: wrapError(err)}
+	return nil
+}
+
+// ImportBlocks returns import blocks in the module
+func (s *Server) ImportBlocks(args interface{}, resp *tflint.ImportBlocksResponse) error {
+	imports := []*tflint.Import{}
+	err := s.runner.WalkImportBlocks(func(imp *tflint.Import) error {
+		for _, attribute := range []**hcl.Attribute{&imp.To, &imp.ID, &imp.Provider, &imp.ForEach} {
+			if *attribute != nil {
+				*attribute = s.wireAttribute(*attribute)
+			}
+		}
+		imports = append(imports, imp)
+		return nil
+	})
+	*resp = tflint.ImportBlocksResponse{Imports: imports, Err: wrapError(err)}
+	return nil
+}
+
+// Conditions returns custom conditions in the module
+func (s *Server) Conditions(args interface{}, resp *tflint.ConditionsResponse) error {
+	conditions := []*tflint.Condition{}
+	err := s.runner.WalkConditions(func(condition *tflint.Condition) error {
+		// Conditions usually have operators that cannot be sent via RPC, so expressions are always sent as the wire representation.
+		for _, attribute := range []**hcl.Attribute{&condition.Condition, &condition.ErrorMessage} {
+			if *attribute != nil {
+				wired := **attribute
+				wired.Expr = tflint.NewWireExpr(wired.Expr, s.runner.Files[wired.Range.Filename].Bytes)
+				*attribute = &wired
+			}
+		}
+		conditions = append(conditions, condition)
+		return nil
+	})
+	*resp = tflint.ConditionsResponse{Conditions: conditions, Err: wrapError(err)}
+	return nil
+}
+
+// TerraformSettings returns terraform blocks in the module
+// Backend blocks in JSON syntax cannot be sent via RPC, so they are omitted.
+func (s *Server) TerraformSettings(args interface{}, resp *tflint.TerraformSettingsResponse) error {
+	settings := []*tflint.TerraformSettings{}
+	err := s.runner.WalkTerraformSettings(func(setting *tflint.TerraformSettings) error {
+		if setting.RequiredVersion != nil {
+			setting.RequiredVersion = s.wireAttribute(setting.RequiredVersion)
+		}
+		for name, attribute := range setting.RequiredProviders {
+			setting.RequiredProviders[name] = s.wireAttribute(attribute)
+		}
+		if setting.Backend != nil {
+			if _, ok := setting.Backend.Body.(*hclsyntax.Body); !ok {
+				setting.Backend = nil
+			}
+		}
+		settings = append(settings, setting)
+		return nil
+	})
+	*resp = tflint.TerraformSettingsResponse{Settings: settings, Err: wrapError(err)}
+	return nil
+}
+
+// Backend returns the backend configured in the module
+func (s *Server) Backend(args interface{}, resp *tflint.BackendResponse) error {
+	backend, err := s.runner.Backend()
+	if backend != nil {
+		for name, attribute := range backend.Attributes {
+			backend.Attributes[name] = s.wireAttribute(attribute)
+		}
+	}
+	*resp = tflint.BackendResponse{Backend: backend, Err: wrapError(err)}
+	return nil
+}
+
+// ModuleCalls returns module calls in the module
+func (s *Server) ModuleCalls(args interface{}, resp *tflint.ModuleCallsResponse) error {
+	calls := []*tflint.ModuleCall{}
+	err := s.runner.WalkModuleCalls(func(call *tflint.ModuleCall) error {
+		for _, attribute := range []**hcl.Attribute{&call.Source, &call.Version} {
+			if *attribute != nil {
+				*attribute = s.wireAttribute(*attribute)
+			}
+		}
+		for name, attribute := range call.Inputs {
+			call.Inputs[name] = s.wireAttribute(attribute)
+		}
+		calls = append(calls, call)
+		return nil
+	})
+	*resp = tflint.ModuleCallsResponse{ModuleCalls: calls, Err: wrapError(err)}
+	return nil
+}
+
+// ProviderConfigs returns provider configurations in the module
+func (s *Server) ProviderConfigs(args interface{}, resp *tflint.ProviderConfigsResponse) error {
+	providers := []*tflint.ProviderConfig{}
+	err := s.runner.WalkProviderConfigs(func(provider *tflint.ProviderConfig) error {
+		for name, attribute := range provider.Attributes {
+			provider.Attributes[name] = s.wireAttribute(attribute)
+		}
+		providers = append(providers, provider)
+		return nil
+	})
+	*resp = tflint.ProviderConfigsResponse{Providers: providers, Err: wrapError(err)}
+	return nil
+}
+
+// Annotations returns `tflint-ignore-line` annotations in the module
+// Only native syntax files are supported.
+func (s *Server) Annotations(args interface{}, resp *tflint.AnnotationsResponse) error {
+	annotations, err := s.runner.GetAnnotations()
+	*resp = tflint.AnnotationsResponse{Annotations: annotations, Err: wrapError(err)}
+	return nil
+}
+
+// FunctionCalls returns all function calls in the module
+func (s *Server) FunctionCalls(args interface{}, resp *tflint.FunctionCallsResponse) error {
+	calls, err := s.runner.GetFunctionCalls()
+	*resp = tflint.FunctionCallsResponse{Calls: calls, Err: wrapError(err)}
+	return nil
+}
+
+// Expressions returns expressions of all attributes in the module
+// All expressions are sent in the wire representation because some native expressions (e.g. operations) cannot be sent via RPC.
+func (s *Server) Expressions(args interface{}, resp *tflint.ExpressionsResponse) error {
+	exprs := []*tflint.WireExpr{}
+	err := s.runner.WalkExpressions(func(expr hcl.Expression) error {
+		exprs = append(exprs, tflint.NewWireExpr(expr, s.runner.Files[expr.Range().Filename].Bytes))
+		return nil
+	})
+	*resp = tflint.ExpressionsResponse{Expressions: exprs, Err: wrapError(err)}
+	return nil
+}
+
+// Files returns the filenames matching the pattern
+func (s *Server) Files(req *tflint.FilesRequest, resp *tflint.FilesResponse) error {
+	filenames, err := s.runner.GetFiles(req.Pattern)
+	*resp = tflint.FilesResponse{Filenames: filenames, Err: wrapError(err)}
+	return nil
+}
+
+// VariableFiles returns the assignments in the variable files
+func (s *Server) VariableFiles(args interface{}, resp *tflint.VariableFilesResponse) error {
+	files, err := s.runner.GetVariableFiles()
+	if err != nil {
+		*resp = tflint.VariableFilesResponse{Err: wrapError(err)}
+		return nil
+	}
+	for _, file := range files {
+		for i, assignment := range file.Assignments {
+			file.Assignments[i] = s.wireAttribute(assignment)
+		}
+	}
+	*resp = tflint.VariableFilesResponse{Files: files}
+	return nil
+}
+
+// EvalExpr returns a value of the passed expression in the same way as the helper Runner
+// Like the actual host, the value is converted into the type implied by Ret.
+func (s *Server) EvalExpr(req *tflint.EvalExprRequest, resp *tflint.EvalExprResponse) error {
+	var val cty.Value
+	if err := s.runner.EvaluateExpr(req.Expr, &val); err != nil {
+		*resp = tflint.EvalExprResponse{Err: wrapError(err)}
+		return nil
+	}
+	if req.Ret != nil && !val.IsWhollyKnown() {
+		*resp = tflint.EvalExprResponse{Err: wrapError(unknownValueError(fmt.Sprintf("%s:%d", req.Expr.Range().Filename, req.Expr.Range().Start.Line)))}
+		return nil
+	}
+	if req.Ret != nil {
+		if ty, err := gocty.ImpliedType(req.Ret); err == nil {
+			converted, err := convert.Convert(val, ty)
+			if err != nil {
+				*resp = tflint.EvalExprResponse{Err: wrapError(err)}
+				return nil
+			}
+			val = converted
+		}
+	}
+	sensitive, _ := s.runner.IsSensitive(req.Expr)
+
+	if !val.IsWhollyKnown() || val.Type().HasDynamicTypes() {
+		src, err := tflint.MarshalValue(val)
+		*resp = tflint.EvalExprResponse{UnknownVal: src, Sensitive: sensitive, Err: wrapError(err)}
+		return nil
+	}
+	*resp = tflint.EvalExprResponse{Val: val, Sensitive: sensitive}
+	return nil
+}
+
+// Source returns the source text of the passed range
+func (s *Server) Source(req *tflint.SourceRequest, resp *tflint.SourceResponse) error {
+	src, err := s.runner.ExprSource(hcl.StaticExpr(cty.NilVal, req.Range))
+	*resp = tflint.SourceResponse{Src: []byte(src), Err: wrapError(err)}
+	return nil
+}
+
+// ProviderConfig returns the evaluated value of the attribute in the provider configuration
+func (s *Server) ProviderConfig(req *tflint.ProviderConfigRequest, resp *tflint.ProviderConfigResponse) error {
+	var val cty.Value
+	exists, err := s.runner.GetProviderConfigValue(req.Provider, req.Name, &val)
+	if err != nil || !exists {
+		*resp = tflint.ProviderConfigResponse{Exists: exists, Err: wrapError(err)}
+		return nil
+	}
+	if req.Ret != nil && !val.IsWhollyKnown() {
+		*resp = tflint.ProviderConfigResponse{Err: wrapError(unknownValueError(fmt.Sprintf("provider.%s.%s", req.Provider, req.Name)))}
+		return nil
+	}
+	if req.Ret != nil {
+		if ty, err := gocty.ImpliedType(req.Ret); err == nil {
+			converted, err := convert.Convert(val, ty)
+			if err != nil {
+				*resp = tflint.ProviderConfigResponse{Err: wrapError(err)}
+				return nil
+			}
+			val = converted
+		}
+	}
+
+	if !val.IsWhollyKnown() || val.Type().HasDynamicTypes() {
+		src, err := tflint.MarshalValue(val)
+		*resp = tflint.ProviderConfigResponse{UnknownVal: src, Exists: true, Err: wrapError(err)}
+		return nil
+	}
+	*resp = tflint.ProviderConfigResponse{Val: val, Exists: true}
+	return nil
+}
+
+// RunMetadata returns metadata about the devhost process
+func (s *Server) RunMetadata(args interface{}, resp *tflint.RunMetadataResponse) error {
+	metadata, err := s.runner.RunMetadata()
+	if err != nil {
+		*resp = tflint.RunMetadataResponse{Err: wrapError(err)}
+		return nil
+	}
+	metadata.TFLintVersion = "devhost"
+	metadata.ModuleRoot = s.root
+	_, metadata.CI = os.LookupEnv("CI")
+
+	*resp = tflint.RunMetadataResponse{Metadata: metadata}
+	return nil
+}
+
+// ModulePath returns the path of the root module because devhost does not load child modules
+func (s *Server) ModulePath(args interface{}, resp *tflint.ModulePathResponse) error {
+	path, err := s.runner.ModulePath()
+	*resp = tflint.ModulePathResponse{Path: path, Err: wrapError(err)}
+	return nil
+}
+
+// IsEmpty returns whether the directory has no Terraform files
+func (s *Server) IsEmpty(args interface{}, resp *tflint.IsEmptyResponse) error {
+	empty, err := s.runner.IsEmpty()
+	*resp = tflint.IsEmptyResponse{Empty: empty, Err: wrapError(err)}
+	return nil
+}
+
+// EmitIssue records the issue emitted from the plugin
+func (s *Server) EmitIssue(req *tflint.EmitIssueRequest, resp *interface{}) error {
+	return s.runner.EmitIssue(req.Rule, req.Message, req.Location, req.Meta)
+}
+
+// EmitRecord records the record emitted from the plugin in query mode
+func (s *Server) EmitRecord(req *tflint.EmitRecordRequest, resp *interface{}) error {
+	return s.runner.EmitRecord(req.Rule, req.Value, req.Location)
+}
+
+// Progress receives a progress update from the plugin
+func (s *Server) Progress(req *tflint.ProgressRequest, resp *interface{}) error {
+	return s.runner.ReportProgress(req.Rule, req.Percent, req.Message)
+}
+
+// Env returns the value of the environment variable
+// Only variables allowed by the -env flag are visible to the plugin.
+func (s *Server) Env(req *tflint.EnvRequest, resp *tflint.EnvResponse) error {
+	value, exists, err := s.runner.LookupEnv(req.Name)
+	*resp = tflint.EnvResponse{Value: value, Exists: exists, Err: wrapError(err)}
+	return nil
+}
+
+// deepCopy copies the passed value into dst through gob, so that types sent via RPC are copied entirely
+func deepCopy(src interface{}, dst interface{}) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		return err
+	}
+	return gob.NewDecoder(&buf).Decode(dst)
+}
+
+// wrapError converts the passed error into tflint.Error.
+// Arbitrary error types such as hcl.Diagnostics are not registered in gob, so they cannot be sent via RPC.
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(tflint.Error); ok {
+		return err
+	}
+	return tflint.Error{
+		Code:    tflint.EvaluationError,
+		Level:   tflint.ErrorLevel,
+		Message: err.Error(),
+	}
+}
+
+// unknownValueError returns an error for unknown values found in the passed place.
+// Unknown values are only sent as they are if the plugin receives them as cty.Value.
+func unknownValueError(where string) error {
+	return tflint.Error{
+		Code:    tflint.UnknownValueError,
+		Level:   tflint.WarningLevel,
+		Message: fmt.Sprintf("Unknown value found in %s", where),
+	}
+}
@@ -0,0 +1,52 @@
+// Command scaffold generates a ready-to-build plugin skeleton.
+// The generated project contains main.go that serves the ruleset, an example rule,
+// table-driven tests using the helper Runner, and a GoReleaser configuration.
+//
+// Usage:
+//
+//	scaffold -name example -module github.com/you/tflint-ruleset-example [dir]
+//
+// The generated plugin requires the same version of the SDK as the scaffold, and its dependencies are resolved by `go mod tidy`.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	name := flag.String("name", "", "name of the ruleset (e.g. aws)")
+	module := flag.String("module", "", "Go module path of the plugin (default: tflint-ruleset-<name>)")
+	version := flag.String("sdk-version", sdkVersion(), "version of the SDK required by the plugin (default: the version of the scaffold)")
+	path := flag.String("sdk-path", "", "local path of the SDK to replace the required version with")
+	flag.Parse()
+
+	if *name == "" {
+		fmt.Fprintln(os.Stderr, "Usage: scaffold -name <name> [-module <path>] [dir]")
+		os.Exit(2)
+	}
+	if *module == "" {
+		*module = "tflint-ruleset-" + *name
+	}
+	dir := filepath.Base(*module)
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if *path != "" {
+		abs, err := filepath.Abs(*path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to resolve the SDK path: %s\n", err)
+			os.Exit(1)
+		}
+		*path = abs
+	}
+
+	if err := Generate(dir, &Project{Name: *name, Module: *module, SDKVersion: *version, SDKPath: *path}); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate the plugin: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Generated the plugin skeleton in %s\n", dir)
+}
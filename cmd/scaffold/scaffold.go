@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"text/template"
+)
+
+const sdkModule = "github.com/terraform-linters/tflint-plugin-sdk"
+
+// Project is the data passed to the templates
+type Project struct {
+	Name   string
+	Module string
+	// SDKVersion is the version of the SDK required by the plugin.
+	SDKVersion string
+	// SDKPath is a local path of the SDK. If set, go.mod replaces the SDK with it (e.g. to try an unreleased SDK).
+	SDKPath string
+}
+
+// Binary is the name of the plugin binary. TFLint discovers plugins by this naming convention.
+func (p *Project) Binary() string {
+	return "tflint-ruleset-" + p.Name
+}
+
+// sdkVersion returns the version of the SDK that the scaffold is built with, so that generated plugins require the same API.
+// It returns an empty string if the version is unknown (e.g. built in the SDK repository).
+func sdkVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == sdkModule && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == sdkModule {
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// Generate renders all templates and resolves dependencies (go.sum) with `go mod tidy`, so the plugin builds as generated.
+// Files are rendered in a temporary directory next to the passed directory and moved at the end,
+// so a failure never leaves a half-written directory. The directory must not exist or must be empty.
+func Generate(dir string, project *Project) error {
+	if entries, err := ioutil.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s already exists", dir)
+	}
+
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempDir(parent, "."+filepath.Base(dir)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := render(tmp, project); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = tmp
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to resolve dependencies: %s\n%s", err, out)
+	}
+
+	if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+// render renders all templates into the passed directory
+func render(dir string, project *Project) error {
+	if project.SDKVersion == "" {
+		if project.SDKPath == "" {
+			return errors.New("SDK version is unknown. Please specify it explicitly")
+		}
+		// Any version works because the SDK is replaced with the local path.
+		project.SDKVersion = "v0.0.0"
+	}
+
+	for path, src := range templates {
+		dest := filepath.Join(dir, filepath.FromSlash(path))
+
+		tmpl, err := template.New(path).Delims("[[", "]]").Parse(src)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		file, err := os.Create(dest)
+		if err != nil {
+			return err
+		}
+		err = tmpl.Execute(file, project)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
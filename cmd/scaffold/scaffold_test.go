@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func Test_render(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scaffold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	project := &Project{Name: "example", Module: "github.com/you/tflint-ruleset-example", SDKVersion: "v0.2.0"}
+	if err := render(dir, project); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	golden := filepath.Join("testdata", "example")
+	for path := range templates {
+		got, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedPath := filepath.Join(golden, filepath.FromSlash(path))
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(expectedPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(expectedPath, got, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		expected, err := ioutil.ReadFile(expectedPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(expected) != string(got) {
+			t.Fatalf("`%s` does not match the golden file:\nexpected: %s\ngot: %s", path, expected, got)
+		}
+	}
+
+	if err := render(dir, &Project{Name: "example", Module: "example"}); err == nil {
+		t.Fatal("Expected an error for the unknown SDK version, but no error occurred")
+	}
+}
+
+func Test_Generate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping building the generated plugin in short mode")
+	}
+
+	sdk, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := ioutil.TempDir("", "scaffold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(parent)
+
+	dir := filepath.Join(parent, "tflint-ruleset-example")
+	if err := Generate(dir, &Project{Name: "example", Module: "tflint-ruleset-example", SDKPath: sdk}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	for _, args := range [][]string{{"build", "./..."}, {"vet", "./..."}, {"test", "./..."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("Failed to run `go %s` in the generated plugin: %s\n%s", args[0], err, out)
+		}
+	}
+
+	// Existing directories are never overwritten.
+	if err := Generate(dir, &Project{Name: "example", Module: "tflint-ruleset-example", SDKPath: sdk}); err == nil {
+		t.Fatal("Expected an error for the existing directory, but no error occurred")
+	}
+
+	// A failure leaves nothing.
+	broken := filepath.Join(parent, "broken")
+	if err := Generate(broken, &Project{Name: "example", Module: "tflint-ruleset-example", SDKPath: filepath.Join(parent, "missing")}); err == nil {
+		t.Fatal("Expected an error for the missing SDK, but no error occurred")
+	}
+	entries, err := ioutil.ReadDir(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected only the generated directory, but got %d entries", len(entries))
+	}
+}
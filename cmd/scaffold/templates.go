@@ -0,0 +1,217 @@
+package main
+
+// templates is a map of file paths and their contents.
+// The delimiters are `[[` and `]]` to avoid conflicts with GoReleaser's templates.
+var templates = map[string]string{
+	"go.mod": `module [[.Module]]
+
+go 1.14
+
+require github.com/terraform-linters/tflint-plugin-sdk [[.SDKVersion]]
+[[- if .SDKPath]]
+
+replace github.com/terraform-linters/tflint-plugin-sdk => [[.SDKPath]]
+[[- end]]
+`,
+
+	"main.go": `package main
+
+import (
+	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"[[.Module]]/rules"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		RuleSet: tflint.RuleSet{
+			Name:    "[[.Name]]",
+			Version: "0.1.0",
+			Rules:   rules.Rules,
+		},
+	})
+}
+`,
+
+	"rules/provider.go": `package rules
+
+import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+// Rules is a list of all rules provided by the plugin
+var Rules = []tflint.Rule{
+	NewAwsInstanceExampleTypeRule(),
+}
+`,
+
+	"rules/aws_instance_example_type.go": `package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AwsInstanceExampleTypeRule checks whether ...
+type AwsInstanceExampleTypeRule struct{}
+
+// NewAwsInstanceExampleTypeRule returns a new rule
+func NewAwsInstanceExampleTypeRule() *AwsInstanceExampleTypeRule {
+	return &AwsInstanceExampleTypeRule{}
+}
+
+// Name returns the rule name
+func (r *AwsInstanceExampleTypeRule) Name() string {
+	return "aws_instance_example_type"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AwsInstanceExampleTypeRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *AwsInstanceExampleTypeRule) Severity() string {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AwsInstanceExampleTypeRule) Link() string {
+	return ""
+}
+
+// Check checks whether ...
+func (r *AwsInstanceExampleTypeRule) Check(runner tflint.Runner) error {
+	return runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+		var instanceType string
+		err := runner.EvaluateExpr(attribute.Expr, &instanceType)
+
+		return runner.EnsureNoError(err, func() error {
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("instance type is %s", instanceType),
+				attribute.Expr.Range(),
+				tflint.Metadata{Expr: attribute.Expr},
+			)
+		})
+	})
+}
+`,
+
+	"rules/aws_instance_example_type_test.go": `package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AwsInstanceExampleType(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "issue found",
+			Content: ` + "`" + `
+resource "aws_instance" "web" {
+    instance_type = "t2.micro"
+}` + "`" + `,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAwsInstanceExampleTypeRule(),
+					Message: "instance type is t2.micro",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 3, Column: 21},
+						End:      hcl.Pos{Line: 3, Column: 31},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAwsInstanceExampleTypeRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"resource.tf": tc.Content})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+`,
+
+	".goreleaser.yml": `project_name: [[.Binary]]
+env:
+  - CGO_ENABLED=0
+builds:
+  - targets:
+      - darwin_amd64
+      - linux_386
+      - linux_amd64
+      - linux_arm
+      - windows_386
+      - windows_amd64
+    hooks:
+      post:
+        - mkdir -p ./dist/raw
+        - cp "{{ .Path }}" ./dist/raw/{{ .ProjectName }}_{{ .Os }}_{{ .Arch }}
+archives:
+  - id: zip
+    name_template: "{{ .ProjectName }}_{{ .Os }}_{{ .Arch }}"
+    format: zip
+    files:
+      - none*
+checksum:
+  name_template: 'checksums.txt'
+changelog:
+  skip: true
+`,
+
+	"Makefile": `default: build
+
+test:
+	go test ./...
+
+build:
+	go build -o [[.Binary]]
+
+install: build
+	mkdir -p ~/.tflint.d/plugins
+	mv ./[[.Binary]] ~/.tflint.d/plugins
+`,
+
+	"README.md": `# TFLint Ruleset [[.Name]]
+
+## Requirements
+
+- TFLint v0.14+
+- Go v1.14
+
+## Building the plugin
+
+` + "```" + `
+$ make
+` + "```" + `
+
+You can easily install the built plugin with the following:
+
+` + "```" + `
+$ make install
+` + "```" + `
+
+Note that you need to enable the plugin in ` + "`.tflint.hcl`" + `:
+
+` + "```hcl" + `
+plugin "[[.Name]]" {
+  enabled = true
+}
+` + "```" + `
+`,
+}
@@ -0,0 +1,5 @@
+module github.com/you/tflint-ruleset-example
+
+go 1.14
+
+require github.com/terraform-linters/tflint-plugin-sdk v0.2.0
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/you/tflint-ruleset-example/rules"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeOpts{
+		RuleSet: tflint.RuleSet{
+			Name:    "example",
+			Version: "0.1.0",
+			Rules:   rules.Rules,
+		},
+	})
+}
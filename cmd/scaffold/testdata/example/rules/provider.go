@@ -0,0 +1,8 @@
+package rules
+
+import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+// Rules is a list of all rules provided by the plugin
+var Rules = []tflint.Rule{
+	NewAwsInstanceExampleTypeRule(),
+}
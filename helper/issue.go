@@ -11,6 +11,14 @@ type Issue struct {
 	Rule    tflint.Rule
 	Message string
 	Range   hcl.Range
+	// Fix is the fix attached to the issue. It is nil if the rule does not propose a fix.
+	Fix *tflint.Fix
+	// Localizable is the localizable message attached to the issue. It is nil if the rule does not provide it.
+	Localizable *tflint.LocalizableMessage
+	// Remediations are the remediation hints attached to the issue.
+	Remediations []tflint.Remediation
+	// Occurrences is a list of all locations when the issue groups multiple occurrences.
+	Occurrences []hcl.Range
 }
 
 // Issues is a list of Issue
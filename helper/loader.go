@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// NewLocalRunner returns a Runner backed by Terraform configuration files (*.tf, *.tf.json) loaded from the passed directory.
+// Variable files (*.tfvars, *.tfvars.json) are loaded into VariableFiles.
+// This allows tools embedding rulesets (e.g. pre-commit hooks, language servers) to run rules without the TFLint host process.
+// Filenames are normalized relative to the directory as described in tflint.NormalizePath.
+//
+// Files are selected with the same rules as Terraform's configuration loader (hashicorp/terraform/configs):
+// hidden files and editor temporary files (e.g. `.main.tf`, `main.tf~`, `#main.tf#`) are ignored.
+// The loader itself is not used because it is internal to Terraform and returns decoded configurations instead of HCL files,
+// and terraform-config-inspect only returns summaries of modules. Rules need HCL files to walk bodies and expressions.
+//
+// Child modules with local sources (e.g. `./modules/vpc`) are loaded into ChildModules recursively.
+// Note that it has the same restrictions as the Runner for testing. Remote modules are not loaded and expressions are evaluated without any context.
+func NewLocalRunner(dir string) (*Runner, error) {
+	return loadModule(dir, dir, map[string]bool{})
+}
+
+// loadModule loads the module in the directory. Loading is a set of directories being loaded to stop cyclic module calls.
+func loadModule(root, dir string, loading map[string]bool) (*Runner, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	loading[abs] = true
+	defer delete(loading, abs)
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &Runner{Files: map[string]*hcl.File{}, Issues: Issues{}, VariableFiles: map[string]*hcl.File{}}
+	parser := hclparse.NewParser()
+
+	for _, entry := range entries {
+		if entry.IsDir() || ignoredFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		name := tflint.NormalizePath(root, path)
+
+		var parse func([]byte, string) (*hcl.File, hcl.Diagnostics)
+		files := runner.Files
+		switch {
+		case strings.HasSuffix(entry.Name(), ".tf"):
+			parse = parser.ParseHCL
+		case strings.HasSuffix(entry.Name(), ".tf.json"):
+			parse = parser.ParseJSON
+		case strings.HasSuffix(entry.Name(), ".tfvars"):
+			parse = parser.ParseHCL
+			files = runner.VariableFiles
+		case strings.HasSuffix(entry.Name(), ".tfvars.json"):
+			parse = parser.ParseJSON
+			files = runner.VariableFiles
+		default:
+			continue
+		}
+
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, diags := parse(src, name)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		files[name] = file
+	}
+
+	err = runner.WalkModuleCalls(func(call *tflint.ModuleCall) error {
+		if call.Source == nil {
+			return nil
+		}
+		var source string
+		if diags := gohcl.DecodeExpression(call.Source.Expr, nil, &source); diags.HasErrors() {
+			// Sources must be literals in Terraform, so it is reported by Terraform instead.
+			return nil
+		}
+		if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+			return nil
+		}
+
+		childDir := filepath.Join(dir, filepath.FromSlash(source))
+		childAbs, err := filepath.Abs(childDir)
+		if err != nil {
+			return err
+		}
+		if loading[childAbs] {
+			return nil
+		}
+
+		child, err := loadModule(root, childDir, loading)
+		if err != nil {
+			return err
+		}
+		if runner.ChildModules == nil {
+			runner.ChildModules = map[string]*Runner{}
+		}
+		runner.ChildModules[call.Name] = child
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return runner, nil
+}
+
+// ignoredFile returns true if the file is ignored by Terraform (hidden files and editor temporary files)
+func ignoredFile(name string) bool {
+	return strings.HasPrefix(name, ".") ||
+		strings.HasSuffix(name, "~") ||
+		(strings.HasPrefix(name, "#") && strings.HasSuffix(name, "#"))
+}
@@ -0,0 +1,152 @@
+package helper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, src := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func filenames(runner *Runner) []string {
+	ret := []string{}
+	for name := range runner.Files {
+		ret = append(ret, name)
+	}
+	for name := range runner.VariableFiles {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func Test_NewLocalRunner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"main.tf": `
+module "vpc" {
+  source = "./modules/vpc"
+  cidr   = var.cidr
+}
+
+module "remote" {
+  source = "terraform-aws-modules/vpc/aws"
+}`,
+		"variables.tf.json":      `{"variable": {"cidr": {}}}`,
+		"terraform.tfvars":       `cidr = "10.0.0.0/16"`,
+		"prod.tfvars.json":       `{"cidr": "10.1.0.0/16"}`,
+		".hidden.tf":             `resource "aws_instance" "hidden" {}`,
+		"main.tf~":               `resource "aws_instance" "backup" {}`,
+		"#main.tf#":              `resource "aws_instance" "autosave" {}`,
+		"README.md":              `# module`,
+		"modules/vpc/main.tf":    `variable "cidr" {}`,
+		"modules/vpc/outputs.tf": `output "cidr" { value = var.cidr }`,
+	})
+
+	runner, err := NewLocalRunner(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []string{"main.tf", "prod.tfvars.json", "terraform.tfvars", "variables.tf.json"}
+	if got := filenames(runner); !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+
+	if len(runner.ChildModules) != 1 {
+		t.Fatalf("Expected only the local module is loaded, but got %d modules", len(runner.ChildModules))
+	}
+	expected = []string{"modules/vpc/main.tf", "modules/vpc/outputs.tf"}
+	if got := filenames(runner.ChildModules["vpc"]); !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+
+	flows, err := runner.GetVariableFlows("vpc")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(flows) != 1 || len(flows[0].Outputs) != 1 {
+		t.Fatalf("Expected the flow of `cidr` into the output, but got %#v", flows)
+	}
+}
+
+func Test_NewLocalRunner_cyclic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"main.tf":        `module "child" { source = "./child" }`,
+		"child/child.tf": `module "parent" { source = "../" }`,
+	})
+
+	runner, err := NewLocalRunner(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	child := runner.ChildModules["child"]
+	if child == nil || len(child.ChildModules) != 0 {
+		t.Fatalf("Expected the cyclic module call is not loaded, but got %#v", child)
+	}
+}
+
+func Test_NewLocalRunner_parseError(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Files map[string]string
+	}{
+		{
+			Name:  "configuration",
+			Files: map[string]string{"main.tf": `resource "aws_instance" {`},
+		},
+		{
+			Name:  "variable file",
+			Files: map[string]string{"terraform.tfvars": `cidr = `},
+		},
+		{
+			Name: "child module",
+			Files: map[string]string{
+				"main.tf":             `module "vpc" { source = "./modules/vpc" }`,
+				"modules/vpc/main.tf": `variable "cidr" {`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		dir, err := ioutil.TempDir("", "loader")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		writeFiles(t, dir, tc.Files)
+
+		if _, err := NewLocalRunner(dir); err == nil {
+			t.Fatalf("Failed `%s` test: expected an error, but no error occurred", tc.Name)
+		}
+	}
+
+	if _, err := NewLocalRunner(filepath.Join(os.TempDir(), "missing-module")); err == nil {
+		t.Fatal("Expected an error for the missing directory, but no error occurred")
+	}
+}
@@ -0,0 +1,112 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// jsonAttributes returns the attributes of the body in JSON syntax in declaration order, and the attributes whose keys
+// are declared more than once. hcl/json reports duplicate keys only as diagnostics without their values,
+// so the values of duplicates are found by scanning the source after their names.
+func jsonAttributes(body hcl.Body, src []byte) ([]*hcl.Attribute, []*hcl.Attribute, error) {
+	attrs, diags := body.JustAttributes()
+
+	duplicates := []*hcl.Attribute{}
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		if diag.Summary != "Duplicate attribute definition" || diag.Subject == nil {
+			return nil, nil, diags
+		}
+		attribute, err := jsonAttributeAt(*diag.Subject, src)
+		if err != nil {
+			return nil, nil, err
+		}
+		duplicates = append(duplicates, attribute)
+	}
+
+	attributes := []*hcl.Attribute{}
+	for _, attribute := range attrs {
+		attributes = append(attributes, attribute)
+	}
+	for _, list := range [][]*hcl.Attribute{attributes, duplicates} {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Range.Start.Byte < list[j].Range.Start.Byte
+		})
+	}
+	return attributes, duplicates, nil
+}
+
+// jsonAttributeAt returns the attribute whose key is at the passed range in the source in JSON syntax.
+// The expression is the wire representation of the value, as values cannot be parsed alone by hcl/json.
+func jsonAttributeAt(nameRange hcl.Range, src []byte) (*hcl.Attribute, error) {
+	var name string
+	if err := json.Unmarshal(nameRange.SliceBytes(src), &name); err != nil {
+		return nil, fmt.Errorf("Failed to read the key at %s: %s", nameRange, err)
+	}
+
+	pos := nameRange.End
+	advance := func(n int) {
+		for _, r := range string(src[pos.Byte : pos.Byte+n]) {
+			if r == '\n' {
+				pos.Line++
+				pos.Column = 1
+			} else {
+				pos.Column++
+			}
+		}
+		pos.Byte += n
+	}
+	skipSpaces := func() {
+		for pos.Byte < len(src) && bytes.IndexByte([]byte(" \t\r\n"), src[pos.Byte]) >= 0 {
+			advance(1)
+		}
+	}
+
+	skipSpaces()
+	if pos.Byte >= len(src) || src[pos.Byte] != ':' {
+		return nil, fmt.Errorf("Failed to read the value of `%s` at %s", name, nameRange)
+	}
+	advance(1)
+	skipSpaces()
+
+	start := pos
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(src[pos.Byte:])).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("Failed to read the value of `%s` at %s: %s", name, nameRange, err)
+	}
+	advance(len(raw))
+	valueRange := hcl.Range{Filename: nameRange.Filename, Start: start, End: pos}
+
+	return &hcl.Attribute{
+		Name:      name,
+		Expr:      &tflint.WireExpr{Version: tflint.WireExprVersion, Syntax: tflint.JSONSyntax, Encoding: tflint.UTF8Encoding, Src: raw, SrcRange: valueRange, Traversals: jsonTraversals(raw)},
+		Range:     hcl.RangeBetween(nameRange, valueRange),
+		NameRange: nameRange,
+	}, nil
+}
+
+// jsonTraversals returns the traversals in the value in JSON syntax interpreted in attribute context (e.g. templates in strings)
+func jsonTraversals(raw []byte) []hcl.Traversal {
+	var src bytes.Buffer
+	src.WriteString(`{"value":`)
+	src.Write(raw)
+	src.WriteString(`}`)
+
+	file, diags := hcljson.Parse(src.Bytes(), "")
+	if diags.HasErrors() {
+		return nil
+	}
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil
+	}
+	return attributes["value"].Expr.Variables()
+}
@@ -1,8 +1,21 @@
 package helper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
 	"github.com/zclconf/go-cty/cty/gocty"
 )
 
@@ -13,10 +26,148 @@ import (
 type Runner struct {
 	Files  map[string]*hcl.File
 	Issues Issues
+	// VariableFiles is a set of variable definitions files (*.tfvars, *.tfvars.json) keyed by filename.
+	VariableFiles map[string]*hcl.File
+	// Metadata is returned by RunMetadata. If nil, metadata about the current process is returned.
+	Metadata *tflint.RunMetadata
+	// CallPath is returned by ModulePath. If empty, the runner acts as the root module.
+	CallPath tflint.ModulePath
+	// Offline is returned by IsOffline.
+	Offline bool
+	// Env is the set of environment variables returned by LookupEnv. Variables not in the set are treated as not existing.
+	Env map[string]string
+	// UnknownVariables is a list of variable names whose values are treated as unknown (e.g. not known until apply).
+	UnknownVariables []string
+	// SensitiveVariables is a list of variable names whose values are treated as sensitive.
+	SensitiveVariables []string
+	// ProgressUpdates is a list of progress updates reported by rules.
+	ProgressUpdates []*tflint.ProgressUpdate
+	// Records is a list of records emitted by rules in query mode.
+	Records []*tflint.Record
+	// Functions is a set of functions available in expression evaluation (e.g. custom functions of plugins).
+	Functions map[string]function.Function
+	// FixedTime is the time returned by Clock. If zero, Clock returns the system time.
+	FixedTime time.Time
+	// Seed is the seed of random number generators returned by Rand.
+	Seed int64
+	// ChildModules is a set of runners of child modules keyed by module call name. It is used by GetVariableFlows.
+	ChildModules map[string]*Runner
 }
 
 // WalkResourceAttributes searches for resources and passes the appropriate attributes to the walker function
+// The resource type can be a glob pattern (e.g. `aws_*`) optionally followed by a name (e.g. `aws_instance.web`),
+// and the attribute name can be a dotted path through nested blocks.
 func (r *Runner) WalkResourceAttributes(resourceType, attributeName string, walker func(*hcl.Attribute) error) error {
+	return r.walkAttributes("resource", resourceType, attributeName, walker)
+}
+
+// WalkResourceAttributesWithFields is the same as WalkResourceAttributes, except that only the selected fields are passed to the walker
+func (r *Runner) WalkResourceAttributesWithFields(resourceType, attributeName string, fields tflint.AttributeFields, walker func(*hcl.Attribute) error) error {
+	return r.walkAttributes("resource", resourceType, attributeName, func(attribute *hcl.Attribute) error {
+		return walker(tflint.ProjectAttribute(attribute, fields))
+	})
+}
+
+// WalkDataSourceAttributes searches for data sources and passes the appropriate attributes to the walker function
+// The data source type can be a glob pattern (e.g. `aws_*`).
+func (r *Runner) WalkDataSourceAttributes(dataSource, attributeName string, walker func(*hcl.Attribute) error) error {
+	return r.walkAttributes("data", dataSource, attributeName, walker)
+}
+
+func (r *Runner) walkAttributes(blockType, typeName, attributeName string, walker func(*hcl.Attribute) error) error {
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       blockType,
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(typeName, resource.Labels[0], resource.Labels[1]) {
+				continue
+			}
+
+			if err := walkAttributePath(resource.Body, strings.Split(attributeName, "."), walker); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkAttributePath passes the attributes addressed by the path to the walker function. The leading segments are nested block types.
+// Unlike lookupAttribute, all nested blocks are walked if declared multiple times.
+func walkAttributePath(body hcl.Body, path []string, walker func(*hcl.Attribute) error) error {
+	if len(path) > 1 {
+		content, _, diags := body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: path[0]}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+		for _, block := range content.Blocks {
+			if err := walkAttributePath(block.Body, path[1:], walker); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	content, _, diags := body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: path[0]}},
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+	if attribute, ok := content.Attributes[path[0]]; ok {
+		return walker(attribute)
+	}
+	return nil
+}
+
+// WalkResourceAttributeValues searches for resources and passes the appropriate attributes and their values to the walker function.
+// Values are evaluated in the same way as EvaluateExpr. If the value is not statically known, cty.DynamicVal is passed.
+func (r *Runner) WalkResourceAttributeValues(resourceType, attributeName string, walker func(*hcl.Attribute, cty.Value) error) error {
+	ctx, err := r.evalContext()
+	if err != nil {
+		return err
+	}
+
+	return r.WalkResourceAttributes(resourceType, attributeName, func(attribute *hcl.Attribute) error {
+		val, diags := attribute.Expr.Value(ctx)
+		if diags.HasErrors() || !val.IsWhollyKnown() {
+			val = cty.DynamicVal
+		}
+		return walker(attribute, val)
+	})
+}
+
+// GetDuplicateValues evaluates the attributes of resources and returns groups of attributes that have the same value
+func (r *Runner) GetDuplicateValues(resourceType, attributeName string) ([]*tflint.DuplicateGroup, error) {
+	attributes := []*tflint.EvaluatedAttribute{}
+	err := r.WalkResourceAttributeValues(resourceType, attributeName, func(attribute *hcl.Attribute, val cty.Value) error {
+		sensitive, err := r.IsSensitive(attribute.Expr)
+		if err != nil {
+			return err
+		}
+		attributes = append(attributes, &tflint.EvaluatedAttribute{Attribute: attribute, Val: &val, Sensitive: sensitive})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tflint.NewDuplicateGroups(attributes), nil
+}
+
+// WalkResourceBlocks searches for resources and passes the appropriate nested blocks to the walker function
+func (r *Runner) WalkResourceBlocks(resourceType, blockType string, walker func(*hcl.Block) error) error {
 	for _, file := range r.Files {
 		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
 			Blocks: []hcl.BlockHeaderSchema{
@@ -31,57 +182,1155 @@ func (r *Runner) WalkResourceAttributes(resourceType, attributeName string, walk
 		}
 
 		for _, resource := range resources.Blocks {
-			if resource.Labels[0] != resourceType {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
 				continue
 			}
 
-			body, _, diags := resource.Body.PartialContent(&hcl.BodySchema{
-				Attributes: []hcl.AttributeSchema{
-					{
-						Name: attributeName,
-					},
+			blocks := hcl.Blocks{}
+			if body, ok := resource.Body.(*hclsyntax.Body); ok {
+				// Walk the syntax tree directly because nested blocks can have any number of labels
+				for _, block := range body.Blocks {
+					if block.Type == blockType {
+						blocks = append(blocks, block.AsHCLBlock())
+					}
+				}
+			} else {
+				content, _, diags := resource.Body.PartialContent(&hcl.BodySchema{
+					Blocks: []hcl.BlockHeaderSchema{{Type: blockType}},
+				})
+				if diags.HasErrors() {
+					return diags
+				}
+				blocks = content.Blocks
+			}
+
+			for _, block := range blocks {
+				if err := walker(block); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// CountResources returns the number of resources of the type
+func (r *Runner) CountResources(resourceType string) (int, error) {
+	count := 0
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+		})
+		if diags.HasErrors() {
+			return 0, diags
+		}
+		for _, resource := range content.Blocks {
+			if tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// topLevelLabelNames is the label names of top-level blocks
+var topLevelLabelNames = map[string][]string{
+	"resource": {"type", "name"},
+	"data":     {"type", "name"},
+	"module":   {"name"},
+	"provider": {"name"},
+	"variable": {"name"},
+	"output":   {"name"},
+}
+
+// CountBlocks returns the number of nested blocks of the type in resources of the type
+// If the resource type is empty, it returns the number of top-level blocks of the type (e.g. "variable").
+func (r *Runner) CountBlocks(resourceType, blockType string) (int, error) {
+	count := 0
+	if resourceType != "" {
+		err := r.WalkResourceBlocks(resourceType, blockType, func(*hcl.Block) error {
+			count++
+			return nil
+		})
+		return count, err
+	}
+
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: blockType, LabelNames: topLevelLabelNames[blockType]}},
+		})
+		if diags.HasErrors() {
+			return 0, diags
+		}
+		count += len(content.Blocks)
+	}
+	return count, nil
+}
+
+// WalkAttributeOrder searches for resources and passes their attributes in declaration order to the walker function.
+// Duplicates is only set for bodies in JSON syntax. In JSON syntax, nested blocks cannot be distinguished from attributes
+// without the schema, so they are also contained in Attributes.
+func (r *Runner) WalkAttributeOrder(resourceType string, walker func(*tflint.AttributeOrder) error) error {
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "resource",
+					LabelNames: []string{"type", "name"},
 				},
-			})
-			if diags.HasErrors() {
-				return diags
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				continue
 			}
 
-			if attribute, ok := body.Attributes[attributeName]; ok {
-				err := walker(attribute)
+			attributes := []*hcl.Attribute{}
+			duplicates := []*hcl.Attribute{}
+			if body, ok := resource.Body.(*hclsyntax.Body); ok {
+				for _, attribute := range body.Attributes {
+					attributes = append(attributes, attribute.AsHCLAttribute())
+				}
+				sort.Slice(attributes, func(i, j int) bool {
+					return attributes[i].Range.Start.Byte < attributes[j].Range.Start.Byte
+				})
+			} else {
+				var err error
+				attributes, duplicates, err = jsonAttributes(resource.Body, file.Bytes)
 				if err != nil {
 					return err
 				}
 			}
+
+			err := walker(&tflint.AttributeOrder{
+				DeclRange:  resource.DefRange,
+				Ranges:     tflint.NewBlockRanges(resource),
+				Attributes: attributes,
+				Duplicates: duplicates,
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-// EvaluateExpr returns a value of the passed expression.
-// Note that there is no evaluation, no type conversion, etc.
-func (r *Runner) EvaluateExpr(expr hcl.Expression, ret interface{}) error {
-	val, diags := expr.Value(&hcl.EvalContext{})
-	if diags.HasErrors() {
-		return diags
+// GetModuleContent extracts the content that matches the passed schema from all files and merges them
+func (r *Runner) GetModuleContent(schema *hcl.BodySchema) (*hcl.BodyContent, error) {
+	content := &hcl.BodyContent{Attributes: hcl.Attributes{}, Blocks: hcl.Blocks{}}
+
+	for _, file := range r.Files {
+		c, _, diags := file.Body.PartialContent(schema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for name, attribute := range c.Attributes {
+			content.Attributes[name] = attribute
+		}
+		content.Blocks = append(content.Blocks, c.Blocks...)
 	}
-	return gocty.FromCtyValue(val, ret)
+
+	return content, nil
 }
 
-// EmitIssue adds an issue into the self
-func (r *Runner) EmitIssue(rule tflint.Rule, message string, location hcl.Range, meta tflint.Metadata) error {
-	r.Issues = append(r.Issues, &Issue{
-		Rule:    rule,
-		Message: message,
-		Range:   location,
-	})
+// GetResourceAttributeNames searches for resources and returns attribute names present in each resource
+// Only native syntax bodies are supported.
+func (r *Runner) GetResourceAttributeNames(resourceType string) ([]*tflint.ResourceAttributeNames, error) {
+	ret := []*tflint.ResourceAttributeNames{}
+
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "resource",
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				continue
+			}
+
+			body, ok := resource.Body.(*hclsyntax.Body)
+			if !ok {
+				continue
+			}
+
+			names := []string{}
+			for name := range body.Attributes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			ret = append(ret, &tflint.ResourceAttributeNames{
+				Type:       resource.Labels[0],
+				Name:       resource.Labels[1],
+				DeclRange:  resource.DefRange,
+				Ranges:     tflint.NewBlockRanges(resource),
+				Attributes: names,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// WalkExpandedResourceBlocks searches for resources, expands dynamic blocks, and passes the appropriate nested blocks to the walker function
+func (r *Runner) WalkExpandedResourceBlocks(resourceType, blockType string, walker func(*tflint.ExpandedBlock) error) error {
+	ctx, err := r.evalContext()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "resource",
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				continue
+			}
+
+			blocks, diags := tflint.NewExpandedBlocks(resource, blockType, ctx)
+			if diags.HasErrors() {
+				return diags
+			}
+			for _, block := range blocks {
+				for _, attribute := range block.Attributes {
+					if attribute.Val != nil {
+						attribute.Sensitive, _ = r.IsSensitive(attribute.Attribute.Expr)
+					}
+				}
+				if err := walker(block); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
-// EnsureNoError is a method that simply run a function if there is no error
-func (r *Runner) EnsureNoError(err error, proc func() error) error {
-	if err == nil {
-		return proc()
+// WalkResources searches for resources and passes them to the walker function
+func (r *Runner) WalkResources(resourceType string, walker func(*tflint.Resource) error) error {
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "resource",
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				continue
+			}
+			if err := walker(tflint.NewResource(resource)); err != nil {
+				return err
+			}
+		}
 	}
-	return err
+
+	return nil
+}
+
+// WalkResourceMetaArguments searches for resources and passes their meta-arguments to the walker function
+func (r *Runner) WalkResourceMetaArguments(resourceType string, walker func(*tflint.MetaArguments) error) error {
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "resource",
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				continue
+			}
+
+			meta, diags := tflint.NewMetaArguments(resource)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(meta); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkResourceProvisioners searches for resources and passes their provisioners to the walker function
+func (r *Runner) WalkResourceProvisioners(resourceType string, walker func(*tflint.Provisioner) error) error {
+	for _, file := range r.Files {
+		resources, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "resource",
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, resource := range resources.Blocks {
+			if !tflint.MatchResourceAddress(resourceType, resource.Labels[0], resource.Labels[1]) {
+				continue
+			}
+
+			provisioners, diags := tflint.NewProvisioners(resource)
+			if diags.HasErrors() {
+				return diags
+			}
+			for _, provisioner := range provisioners {
+				if err := walker(provisioner); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkLocals searches for local values and passes them to the walker function
+func (r *Runner) WalkLocals(walker func(string, hcl.Expression) error) error {
+	locals, err := r.GetLocals()
+	if err != nil {
+		return err
+	}
+
+	for _, local := range locals {
+		if err := walker(local.Name, local.Expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLocals returns the attributes of all locals blocks in the files
+// Locals are sorted by filename and declaration order.
+func (r *Runner) GetLocals() ([]*hcl.Attribute, error) {
+	names := make([]string, 0, len(r.Files))
+	for name := range r.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := []*hcl.Attribute{}
+	for _, name := range names {
+		content, _, diags := r.Files[name].Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "locals"}},
+		})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range content.Blocks {
+			attributes, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				return nil, diags
+			}
+
+			locals := make([]*hcl.Attribute, 0, len(attributes))
+			for _, attribute := range attributes {
+				locals = append(locals, attribute)
+			}
+			sort.Slice(locals, func(i, j int) bool {
+				return locals[i].Range.Start.Byte < locals[j].Range.Start.Byte
+			})
+			ret = append(ret, locals...)
+		}
+	}
+	return ret, nil
+}
+
+// WalkVariables searches for variable blocks and passes them to the walker function
+func (r *Runner) WalkVariables(walker func(*tflint.Variable) error) error {
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "variable",
+					LabelNames: []string{"name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			variable, diags := tflint.NewVariable(block)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(variable); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkOutputs searches for output blocks and passes them to the walker function
+func (r *Runner) WalkOutputs(walker func(*tflint.Output) error) error {
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "output",
+					LabelNames: []string{"name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			output, diags := tflint.NewOutput(block)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(output); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkMovedBlocks searches for moved blocks and passes them to the walker function
+func (r *Runner) WalkMovedBlocks(walker func(*tflint.Moved) error) error {
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "moved"}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			moved, diags := tflint.NewMoved(block)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(moved); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkImportBlocks searches for import blocks and passes them to the walker function
+func (r *Runner) WalkImportBlocks(walker func(*tflint.Import) error) error {
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "import"}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			imp, diags := tflint.NewImport(block)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(imp); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkConditions searches for check assertions and precondition/postcondition blocks and passes them to the walker function
+func (r *Runner) WalkConditions(walker func(*tflint.Condition) error) error {
+	for _, file := range r.Files {
+		conditions, diags := tflint.FindConditions(file)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, condition := range conditions {
+			if err := walker(condition); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WalkTerraformSettings searches for terraform blocks and passes them to the walker function
+func (r *Runner) WalkTerraformSettings(walker func(*tflint.TerraformSettings) error) error {
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			settings, diags := tflint.NewTerraformSettings(block)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(settings); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Backend returns the first backend declared in terraform blocks, or nil if not configured
+func (r *Runner) Backend() (*tflint.Backend, error) {
+	var backend *tflint.Backend
+	err := r.WalkTerraformSettings(func(settings *tflint.TerraformSettings) error {
+		if backend != nil || settings.Backend == nil {
+			return nil
+		}
+		var diags hcl.Diagnostics
+		backend, diags = tflint.NewBackend(settings.Backend)
+		if diags.HasErrors() {
+			return diags
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+// WalkModuleCalls searches for module blocks and passes them to the walker function
+func (r *Runner) WalkModuleCalls(walker func(*tflint.ModuleCall) error) error {
+	for _, file := range r.Files {
+		modules, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, module := range modules.Blocks {
+			call, diags := tflint.NewModuleCall(module)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(call); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetFunctionCalls returns all function calls in the files
+// Only native syntax files are supported.
+func (r *Runner) GetFunctionCalls() ([]*tflint.FunctionCall, error) {
+	names := make([]string, 0, len(r.Files))
+	for name := range r.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	calls := []*tflint.FunctionCall{}
+	for _, name := range names {
+		calls = append(calls, tflint.FindFunctionCalls(r.Files[name])...)
+	}
+	return calls, nil
+}
+
+// GetVariableFlows returns the flows of the arguments of the passed module call into the child module in ChildModules
+// If the child module is not set, nil is returned like the actual Runner returns it for modules not installed.
+func (r *Runner) GetVariableFlows(module string) ([]*tflint.VariableFlow, error) {
+	child, ok := r.ChildModules[module]
+	if !ok {
+		return nil, nil
+	}
+
+	var call *tflint.ModuleCall
+	err := r.WalkModuleCalls(func(c *tflint.ModuleCall) error {
+		if c.Name == module {
+			call = c
+		}
+		return nil
+	})
+	if err != nil || call == nil {
+		return nil, err
+	}
+
+	variables := []*tflint.Variable{}
+	if err := child.WalkVariables(func(variable *tflint.Variable) error {
+		variables = append(variables, variable)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	outputs := []*tflint.Output{}
+	if err := child.WalkOutputs(func(output *tflint.Output) error {
+		outputs = append(outputs, output)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	exprs := []hcl.Expression{}
+	if err := child.WalkExpressions(func(expr hcl.Expression) error {
+		exprs = append(exprs, expr)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return tflint.NewVariableFlows(call, variables, outputs, exprs), nil
+}
+
+// WalkExpressions passes the expressions of all attributes in the files to the walker function in source order
+// Only native syntax files are supported.
+func (r *Runner) WalkExpressions(walker func(hcl.Expression) error) error {
+	names := make([]string, 0, len(r.Files))
+	for name := range r.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		body, ok := r.Files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		attributes := []*hclsyntax.Attribute{}
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if attribute, ok := node.(*hclsyntax.Attribute); ok {
+				attributes = append(attributes, attribute)
+			}
+			return nil
+		})
+		sort.Slice(attributes, func(i, j int) bool {
+			return attributes[i].SrcRange.Start.Byte < attributes[j].SrcRange.Start.Byte
+		})
+
+		for _, attribute := range attributes {
+			if err := walker(attribute.Expr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetAnnotations returns `tflint-ignore-line` annotations in the files
+// Only native syntax files are supported.
+func (r *Runner) GetAnnotations() (tflint.Annotations, error) {
+	names := make([]string, 0, len(r.Files))
+	for name := range r.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := tflint.Annotations{}
+	for _, name := range names {
+		file := r.Files[name]
+		if _, ok := file.Body.(*hclsyntax.Body); !ok {
+			continue
+		}
+		annotations, diags := tflint.NewAnnotations(name, file.Bytes)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		ret = append(ret, annotations...)
+	}
+	return ret, nil
+}
+
+// GetFiles returns the names of the files (including variable files) matching the passed glob pattern in sorted order
+func (r *Runner) GetFiles(pattern string) ([]string, error) {
+	filenames := []string{}
+	for _, files := range []map[string]*hcl.File{r.Files, r.VariableFiles} {
+		for name := range files {
+			matched, err := tflint.MatchGlob(pattern, name)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				filenames = append(filenames, name)
+			}
+		}
+	}
+	sort.Strings(filenames)
+	return filenames, nil
+}
+
+// GetVariableFiles returns the assignments in VariableFiles sorted by filename
+func (r *Runner) GetVariableFiles() ([]*tflint.VariableFile, error) {
+	names := make([]string, 0, len(r.VariableFiles))
+	for name := range r.VariableFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := []*tflint.VariableFile{}
+	for _, name := range names {
+		file, diags := tflint.NewVariableFile(name, r.VariableFiles[name])
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// EvaluateExpr evaluates the passed expression and sets the value to ret.
+// Only variables (`var.*`) can be referred, and their values are the defaults in the files.
+// Variables that have no default or are listed in UnknownVariables are treated as unknown values.
+// Built-in functions that have no side effects (e.g. lower, join) and Functions are available.
+// Expressions referring to other values or calling other functions result in UnevaluableError as a warning.
+// If ret is *cty.Value, the value is set as is, including unknown values. Otherwise, unknown values result in
+// UnknownValueError as a warning, and the value is converted into the type implied by ret (e.g. map, slice, struct).
+func (r *Runner) EvaluateExpr(expr hcl.Expression, ret interface{}) error {
+	ctx, err := r.evalContext()
+	if err != nil {
+		return err
+	}
+
+	// Like the actual Runner, expressions that cannot be evaluated statically are reported as a warning, not an evaluation error.
+	if !evaluable(expr, ctx) {
+		return tflint.Error{
+			Code:    tflint.UnevaluableError,
+			Level:   tflint.WarningLevel,
+			Message: fmt.Sprintf("Unevaluable expression found in %s:%d", expr.Range().Filename, expr.Range().Start.Line),
+		}
+	}
+
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return diags
+	}
+	// Like the actual Runner, cty.Value is set as is, including unknown values.
+	if ret, ok := ret.(*cty.Value); ok {
+		*ret = val
+		return nil
+	}
+	if !val.IsWhollyKnown() {
+		return tflint.Error{
+			Code:    tflint.UnknownValueError,
+			Level:   tflint.WarningLevel,
+			Message: fmt.Sprintf("Unknown value found in %s:%d", expr.Range().Filename, expr.Range().Start.Line),
+		}
+	}
+
+	// Like the actual Runner, decoders registered by tflint.RegisterDecoder take precedence.
+	if decoded, err := tflint.DecodeValue(val, ret, expr.Range()); decoded {
+		return err
+	}
+	// Like the actual host, the value is converted into the type implied by ret (e.g. object to map).
+	if ty, err := gocty.ImpliedType(ret); err == nil {
+		converted, err := convert.Convert(val, ty)
+		if err != nil {
+			return err
+		}
+		val = converted
+	}
+	return gocty.FromCtyValue(val, ret)
+}
+
+// IsNullExpr returns true if the passed expression evaluates to null
+func (r *Runner) IsNullExpr(expr hcl.Expression) (bool, error) {
+	var val cty.Value
+	if err := r.EvaluateExpr(expr, &val); err != nil {
+		return false, err
+	}
+	return val.IsNull(), nil
+}
+
+var providerConfigSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"name"}}},
+}
+
+// WalkProviderConfigs searches for provider blocks and passes them to the walker function
+func (r *Runner) WalkProviderConfigs(walker func(*tflint.ProviderConfig) error) error {
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(providerConfigSchema)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			config, diags := tflint.NewProviderConfig(block)
+			if diags.HasErrors() {
+				return diags
+			}
+			if err := walker(config); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetProviderConfigValue evaluates the attribute of the provider configuration and reflects it in ret
+// The provider is the name with an optional alias (e.g. "aws.west"), and the name is a dotted path
+// whose leading segments are nested block types (e.g. "features.key_vault.purge_soft_delete_on_destroy").
+func (r *Runner) GetProviderConfigValue(provider, name string, ret interface{}) (bool, error) {
+	attribute, err := r.providerConfigAttribute(provider, name)
+	if err != nil || attribute == nil {
+		return false, err
+	}
+	return true, r.EvaluateExpr(attribute.Expr, ret)
+}
+
+func (r *Runner) providerConfigAttribute(provider, name string) (*hcl.Attribute, error) {
+	path := strings.Split(name, ".")
+
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(providerConfigSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range content.Blocks {
+			config, diags := tflint.NewProviderConfig(block)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			if config.Key() != provider {
+				continue
+			}
+
+			return lookupAttribute(block.Body, path)
+		}
+	}
+
+	return nil, nil
+}
+
+// lookupAttribute returns the attribute addressed by the path. The leading segments are nested block types.
+// If a nested block is declared multiple times, the first one is used.
+func lookupAttribute(body hcl.Body, path []string) (*hcl.Attribute, error) {
+	for _, blockType := range path[:len(path)-1] {
+		content, _, diags := body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: blockType}},
+		})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if len(content.Blocks) == 0 {
+			return nil, nil
+		}
+		body = content.Blocks[0].Body
+	}
+
+	content, _, diags := body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: path[len(path)-1]}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return content.Attributes[path[len(path)-1]], nil
+}
+
+// IsSensitive returns true if the passed expression refers to variables listed in SensitiveVariables
+func (r *Runner) IsSensitive(expr hcl.Expression) (bool, error) {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok && contains(r.SensitiveVariables, attr.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ExprSource returns the source text of the passed expression in the loaded files
+func (r *Runner) ExprSource(expr hcl.Expression) (string, error) {
+	rng := expr.Range()
+	file, ok := r.Files[rng.Filename]
+	if !ok {
+		file, ok = r.VariableFiles[rng.Filename]
+	}
+	if !ok {
+		return "", fmt.Errorf("file not found: %s", rng.Filename)
+	}
+	if rng.Start.Byte > rng.End.Byte || rng.End.Byte > len(file.Bytes) {
+		return "", fmt.Errorf("invalid range: %s", rng)
+	}
+	return string(rng.SliceBytes(file.Bytes)), nil
+}
+
+func (r *Runner) evalContext() (*hcl.EvalContext, error) {
+	variables := map[string]cty.Value{}
+
+	for _, file := range r.Files {
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "variable",
+					LabelNames: []string{"name"},
+				},
+			},
+		})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, variable := range content.Blocks {
+			name := variable.Labels[0]
+
+			attributes, _ := variable.Body.JustAttributes()
+			attribute, exists := attributes["default"]
+			if !exists || contains(r.UnknownVariables, name) {
+				variables[name] = cty.DynamicVal
+				continue
+			}
+
+			val, diags := attribute.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			variables[name] = val
+		}
+	}
+
+	functions := map[string]function.Function{}
+	for name, fn := range builtinFunctions {
+		functions[name] = fn
+	}
+	for name, fn := range r.Functions {
+		functions[name] = fn
+	}
+
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)},
+		Functions: functions,
+	}, nil
+}
+
+// evaluable returns false if the passed expression refers to values other than variables (e.g. resource attributes, locals)
+// or calls functions not available in the passed context. Such expressions cannot be evaluated statically.
+func evaluable(expr hcl.Expression, ctx *hcl.EvalContext) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" {
+			return false
+		}
+	}
+
+	native, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return true
+	}
+	ret := true
+	hclsyntax.VisitAll(native, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			if _, exists := ctx.Functions[call.Name]; !exists {
+				ret = false
+			}
+		}
+		return nil
+	})
+	return ret
+}
+
+// EmitIssue adds an issue into the self
+// Like the actual Runner, identical issues are added only once if the rule implements tflint.DeduplicatedRule.
+// Like the actual Runner, issues on lines annotated with `tflint-ignore-line` for the rule are not emitted.
+func (r *Runner) EmitIssue(rule tflint.Rule, message string, location hcl.Range, meta tflint.Metadata) error {
+	annotations, err := r.GetAnnotations()
+	if err != nil {
+		return err
+	}
+	if annotations.Ignores(rule.Name(), location) {
+		return nil
+	}
+
+	if deduplicated, ok := rule.(tflint.DeduplicatedRule); ok && deduplicated.DeduplicateIssues() {
+		for _, issue := range r.Issues {
+			if issue.Rule.Name() == rule.Name() && issue.Message == message && issue.Range == location {
+				return nil
+			}
+		}
+	}
+
+	var fix *tflint.Fix
+	if meta.Fix != nil {
+		copied := *meta.Fix
+		copied.Rule = rule.Name()
+		fix = &copied
+	}
+
+	r.Issues = append(r.Issues, &Issue{
+		Rule:         rule,
+		Message:      message,
+		Range:        location,
+		Fix:          fix,
+		Localizable:  meta.Localizable,
+		Remediations: meta.Remediations,
+		Occurrences:  meta.Occurrences,
+	})
+	return nil
+}
+
+// ReportProgress adds a progress update into the self
+// Like the actual Runner, the percent is clamped to 100, and negative values are normalized to -1.
+func (r *Runner) ReportProgress(rule tflint.Rule, percent int, message string) error {
+	if percent > 100 {
+		percent = 100
+	}
+	if percent < 0 {
+		percent = -1
+	}
+
+	r.ProgressUpdates = append(r.ProgressUpdates, &tflint.ProgressUpdate{Rule: rule.Name(), Percent: percent, Message: message})
+	return nil
+}
+
+// EmitRecord adds a record into the self
+func (r *Runner) EmitRecord(rule tflint.Rule, value cty.Value, location hcl.Range) error {
+	r.Records = append(r.Records, &tflint.Record{Rule: rule.Name(), Value: value, Location: location})
+	return nil
+}
+
+// EnsureNoError is a method that simply run a function if there is no error
+// Like the actual Runner, warnings (e.g. unknown values) are ignored without running the function.
+// This keeps rule tests on the same branches as TFLint now that the Runner can return unknown values (see UnknownVariables).
+// Other errors are returned as is.
+func (r *Runner) EnsureNoError(err error, proc func() error) error {
+	if err == nil {
+		return proc()
+	}
+	var appErr tflint.Error
+	if errors.As(err, &appErr) && appErr.Level == tflint.WarningLevel {
+		return nil
+	}
+	return err
+}
+
+// RunMetadata returns the Metadata field, or metadata about the current process if not set
+func (r *Runner) RunMetadata() (*tflint.RunMetadata, error) {
+	if r.Metadata != nil {
+		return r.Metadata, nil
+	}
+	return &tflint.RunMetadata{
+		StartTime: time.Now(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		FixedTime: r.FixedTime,
+		Seed:      r.Seed,
+	}, nil
+}
+
+// Clock returns the Clock frozen at FixedTime
+// Like the actual Runner, it is based on the metadata, so it is affected by the Metadata field.
+func (r *Runner) Clock() (tflint.Clock, error) {
+	metadata, err := r.RunMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return tflint.NewClock(metadata.FixedTime), nil
+}
+
+// Rand returns a random number generator for the rule seeded with Seed
+// Like the actual Runner, it is based on the metadata, so it is affected by the Metadata field.
+func (r *Runner) Rand(rule tflint.Rule) (*rand.Rand, error) {
+	metadata, err := r.RunMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return tflint.NewRand(metadata.Seed, rule.Name()), nil
+}
+
+// ModulePath returns the CallPath field
+func (r *Runner) ModulePath() (tflint.ModulePath, error) {
+	return r.CallPath, nil
+}
+
+// ModuleName returns the name of the module call in CallPath
+func (r *Runner) ModuleName() (string, error) {
+	return r.CallPath.Name(), nil
+}
+
+// IsEmpty returns true if the Files field has no files
+func (r *Runner) IsEmpty() (bool, error) {
+	return len(r.Files) == 0, nil
+}
+
+// LookupEnv returns the value of the environment variable in the Env field
+func (r *Runner) LookupEnv(name string) (string, bool, error) {
+	value, exists := r.Env[name]
+	return value, exists, nil
+}
+
+// IsOffline returns the Offline field
+func (r *Runner) IsOffline() bool {
+	return r.Offline
+}
+
+// Context returns a context that is never canceled
+func (r *Runner) Context() context.Context {
+	return context.Background()
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,588 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_EvaluateExpr_variables(t *testing.T) {
+	src := `
+variable "name" {
+  default = "web"
+}
+
+variable "computed" {}
+
+resource "aws_instance" "web" {
+  name = var.name
+  tag  = var.computed
+}`
+
+	cases := []struct {
+		Name       string
+		Attribute  string
+		Unknowns   []string
+		Sensitives []string
+		Expected   string
+		Sensitive  bool
+		Unknown    bool
+	}{
+		{
+			Name:      "default",
+			Attribute: "name",
+			Expected:  "web",
+		},
+		{
+			Name:      "no default",
+			Attribute: "tag",
+			Unknown:   true,
+		},
+		{
+			Name:      "unknown",
+			Attribute: "name",
+			Unknowns:  []string{"name"},
+			Unknown:   true,
+		},
+		{
+			Name:       "sensitive",
+			Attribute:  "name",
+			Sensitives: []string{"name"},
+			Expected:   "web",
+			Sensitive:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		runner := TestRunner(t, map[string]string{"main.tf": src})
+		runner.UnknownVariables = tc.Unknowns
+		runner.SensitiveVariables = tc.Sensitives
+
+		err := runner.WalkResourceAttributes("aws_instance", tc.Attribute, func(attribute *hcl.Attribute) error {
+			var val string
+			err := runner.EvaluateExpr(attribute.Expr, &val)
+			if tc.Unknown {
+				if appErr, ok := err.(tflint.Error); !ok || appErr.Code != tflint.UnknownValueError {
+					t.Fatalf("Failed `%s` test: expected an unknown value error, but got %v", tc.Name, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if val != tc.Expected {
+				t.Fatalf("Failed `%s` test: expected %s, but got %s", tc.Name, tc.Expected, val)
+			}
+
+			sensitive, err := runner.IsSensitive(attribute.Expr)
+			if err != nil {
+				return err
+			}
+			if sensitive != tc.Sensitive {
+				t.Fatalf("Failed `%s` test: expected sensitive is %t, but got %t", tc.Name, tc.Sensitive, sensitive)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+	}
+}
+
+func Test_EvaluateExpr_literals(t *testing.T) {
+	runner := TestRunner(t, map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+  count         = 1 + 2
+}`})
+
+	got := []string{}
+	for _, name := range []string{"instance_type", "count"} {
+		err := runner.WalkResourceAttributes("aws_instance", name, func(attribute *hcl.Attribute) error {
+			var val string
+			if err := runner.EvaluateExpr(attribute.Expr, &val); err != nil {
+				return err
+			}
+			got = append(got, val)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	expected := []string{"t2.micro", "3"}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+}
+
+func Test_EnsureNoError(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Error    error
+		Called   bool
+		Expected error
+	}{
+		{
+			Name:   "no error",
+			Error:  nil,
+			Called: true,
+		},
+		{
+			Name:     "native error",
+			Error:    errors.New("Error occurred"),
+			Expected: errors.New("Error occurred"),
+		},
+		{
+			Name:     "error level",
+			Error:    tflint.Error{Level: tflint.ErrorLevel, Message: "Error occurred"},
+			Expected: tflint.Error{Level: tflint.ErrorLevel, Message: "Error occurred"},
+		},
+		{
+			Name:  "warning level",
+			Error: tflint.Error{Code: tflint.UnknownValueError, Level: tflint.WarningLevel},
+		},
+		{
+			Name:  "wrapped warning level",
+			Error: fmt.Errorf("wrapped: %w", tflint.Error{Code: tflint.UnknownValueError, Level: tflint.WarningLevel}),
+		},
+	}
+
+	runner := TestRunner(t, map[string]string{})
+	for _, tc := range cases {
+		called := false
+		err := runner.EnsureNoError(tc.Error, func() error {
+			called = true
+			return nil
+		})
+		if called != tc.Called {
+			t.Fatalf("Failed `%s` test: expected called is %t, but got %t", tc.Name, tc.Called, called)
+		}
+		if fmt.Sprint(err) != fmt.Sprint(tc.Expected) {
+			t.Fatalf("Failed `%s` test: expected error is %v, but got %v", tc.Name, tc.Expected, err)
+		}
+	}
+}
+
+func Test_EvaluateExpr_functions(t *testing.T) {
+	src := `
+variable "name" {
+  default = "Web"
+}
+
+resource "aws_instance" "web" {
+  name    = format("%s-%s", lower(var.name), "prod")
+  ami     = data.aws_ami.ubuntu.id
+  content = file("user_data.sh")
+}`
+
+	cases := []struct {
+		Attribute string
+		Expected  string
+		Code      string
+	}{
+		{Attribute: "name", Expected: "web-prod"},
+		{Attribute: "ami", Code: tflint.UnevaluableError},
+		{Attribute: "content", Code: tflint.UnevaluableError},
+	}
+
+	runner := TestRunner(t, map[string]string{"main.tf": src})
+	for _, tc := range cases {
+		err := runner.WalkResourceAttributes("aws_instance", tc.Attribute, func(attribute *hcl.Attribute) error {
+			var val string
+			err := runner.EvaluateExpr(attribute.Expr, &val)
+			if tc.Code != "" {
+				if appErr, ok := err.(tflint.Error); !ok || appErr.Code != tc.Code || appErr.Level != tflint.WarningLevel {
+					t.Fatalf("Failed `%s` test: expected %s, but got %v", tc.Attribute, tc.Code, err)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if val != tc.Expected {
+				t.Fatalf("Failed `%s` test: expected %s, but got %s", tc.Attribute, tc.Expected, val)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Attribute, err)
+		}
+	}
+}
+
+func Test_EvaluateExpr_ctyValue(t *testing.T) {
+	runner := TestRunner(t, map[string]string{"main.tf": `
+variable "computed" {}
+
+resource "aws_instance" "web" {
+  tags = { Name = "web", Env = var.computed }
+}`})
+
+	err := runner.WalkResourceAttributes("aws_instance", "tags", func(attribute *hcl.Attribute) error {
+		var val cty.Value
+		if err := runner.EvaluateExpr(attribute.Expr, &val); err != nil {
+			return err
+		}
+		if !val.Type().IsObjectType() || val.IsWhollyKnown() {
+			t.Fatalf("Expected an object with unknown values, but got %#v", val)
+		}
+		if !val.GetAttr("Name").RawEquals(cty.StringVal("web")) || val.GetAttr("Env").IsKnown() {
+			t.Fatalf("Unexpected value: %#v", val)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
+
+func Test_GetVariableFiles(t *testing.T) {
+	runner := TestRunner(t, map[string]string{
+		"main.tf": `variable "region" {}`,
+		"prod.tfvars": `
+region   = "us-east-1"
+password = "secret"`,
+	})
+
+	files, err := runner.GetVariableFiles()
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(files) != 1 || files[0].Filename != "prod.tfvars" {
+		t.Fatalf("Unexpected files: %#v", files)
+	}
+
+	names := []string{}
+	for _, assignment := range files[0].Assignments {
+		names = append(names, assignment.Name)
+	}
+	if len(names) != 2 || names[0] != "region" || names[1] != "password" {
+		t.Fatalf("Unexpected assignments: %#v", names)
+	}
+	if files[0].Assignment("password") == nil || files[0].Assignment("unknown") != nil {
+		t.Fatal("Unexpected result of Assignment")
+	}
+
+	filenames, err := runner.GetFiles("*.tfvars")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(filenames) != 1 || filenames[0] != "prod.tfvars" {
+		t.Fatalf("Unexpected filenames: %#v", filenames)
+	}
+}
+
+func Test_WalkResourceAttributes_wildcard(t *testing.T) {
+	runner := TestRunner(t, map[string]string{
+		"main.tf": `
+resource "aws_instance" "web" {
+  tags = { Name = "web" }
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = { Name = "logs" }
+}
+
+resource "google_compute_instance" "web" {
+  tags = ["web"]
+}`,
+	})
+
+	lines := []int{}
+	err := runner.WalkResourceAttributes("aws_*", "tags", func(attribute *hcl.Attribute) error {
+		lines = append(lines, attribute.Range.Start.Line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(lines) != 2 || lines[0] != 3 || lines[1] != 7 {
+		t.Fatalf("Unexpected attributes: %#v", lines)
+	}
+
+	lines = []int{}
+	err = runner.WalkResourceAttributes("*.web", "tags", func(attribute *hcl.Attribute) error {
+		lines = append(lines, attribute.Range.Start.Line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(lines) != 2 || lines[0] != 3 || lines[1] != 11 {
+		t.Fatalf("Unexpected attributes: %#v", lines)
+	}
+}
+
+func Test_WalkResourceAttributes_path(t *testing.T) {
+	runner := TestRunner(t, map[string]string{
+		"main.tf": `
+resource "aws_instance" "web" {
+  root_block_device {
+    volume_size = 16
+  }
+
+  ebs_block_device {
+    volume_size = 32
+  }
+
+  ebs_block_device {
+    volume_size = 64
+  }
+}`,
+	})
+
+	cases := []struct {
+		Path     string
+		Expected []int
+	}{
+		{Path: "root_block_device.volume_size", Expected: []int{4}},
+		{Path: "ebs_block_device.volume_size", Expected: []int{8, 12}},
+		{Path: "ebs_block_device.volume_type", Expected: []int{}},
+		{Path: "network_interface.device_index", Expected: []int{}},
+	}
+
+	for _, tc := range cases {
+		lines := []int{}
+		err := runner.WalkResourceAttributes("aws_instance", tc.Path, func(attribute *hcl.Attribute) error {
+			lines = append(lines, attribute.Range.Start.Line)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Path, err)
+		}
+		if !cmp.Equal(tc.Expected, lines) {
+			t.Fatalf("Failed `%s` test: diff: %s", tc.Path, cmp.Diff(tc.Expected, lines))
+		}
+	}
+}
+
+func Test_GetProviderConfigValue(t *testing.T) {
+	src := `
+provider "azurerm" {
+  features {
+    key_vault {
+      purge_soft_delete_on_destroy = false
+    }
+  }
+}
+
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}`
+
+	cases := []struct {
+		Name     string
+		Provider string
+		Path     string
+		Exists   bool
+		Expected string
+	}{
+		{
+			Name:     "attribute",
+			Provider: "aws",
+			Path:     "region",
+			Exists:   true,
+			Expected: "us-east-1",
+		},
+		{
+			Name:     "alias",
+			Provider: "aws.west",
+			Path:     "region",
+			Exists:   true,
+			Expected: "us-west-2",
+		},
+		{
+			Name:     "nested block",
+			Provider: "azurerm",
+			Path:     "features.key_vault.purge_soft_delete_on_destroy",
+			Exists:   true,
+			Expected: "false",
+		},
+		{
+			Name:     "missing nested block",
+			Provider: "azurerm",
+			Path:     "features.virtual_machine.delete_os_disk_on_deletion",
+		},
+		{
+			Name:     "missing attribute",
+			Provider: "aws",
+			Path:     "profile",
+		},
+		{
+			Name:     "missing provider",
+			Provider: "google",
+			Path:     "project",
+		},
+	}
+
+	runner := TestRunner(t, map[string]string{"main.tf": src})
+
+	for _, tc := range cases {
+		var got string
+		exists, err := runner.GetProviderConfigValue(tc.Provider, tc.Path, &got)
+		if err != nil {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, err)
+		}
+		if exists != tc.Exists {
+			t.Fatalf("Failed `%s` test: expected exists=%t, but got %t", tc.Name, tc.Exists, exists)
+		}
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected `%s`, but got `%s`", tc.Name, tc.Expected, got)
+		}
+	}
+}
+
+type testRule struct{}
+
+func (r *testRule) Name() string              { return "test_rule" }
+func (r *testRule) Enabled() bool             { return true }
+func (r *testRule) Severity() string          { return tflint.ERROR }
+func (r *testRule) Link() string              { return "" }
+func (r *testRule) Check(tflint.Runner) error { return nil }
+
+func Test_EmitIssue_annotation(t *testing.T) {
+	runner := TestRunner(t, map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  instance_type = "t1.2xlarge" # tflint-ignore-line: test_rule
+  ami           = "ami-1234"   # tflint-ignore-line: other_rule
+}`})
+
+	for _, line := range []int{3, 4} {
+		location := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: line, Column: 3}}
+		if err := runner.EmitIssue(&testRule{}, "test", location, tflint.Metadata{}); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	if len(runner.Issues) != 1 || runner.Issues[0].Range.Start.Line != 4 {
+		t.Fatalf("Expected only the issue on line 4, but got %#v", runner.Issues)
+	}
+}
+
+func Test_GetVariableFlows(t *testing.T) {
+	runner := TestRunner(t, map[string]string{"main.tf": `
+variable "password" {
+  sensitive = true
+}
+
+module "db" {
+  source = "./db"
+
+  password = var.password
+  engine   = "mysql"
+  typo     = "foo"
+}`})
+	runner.ChildModules = map[string]*Runner{
+		"db": TestRunner(t, map[string]string{"db.tf": `
+variable "password" {}
+variable "engine" {}
+
+resource "aws_db_instance" "main" {
+  engine   = var.engine
+  password = var.password
+}
+
+output "connection" {
+  value = "${var.engine}://admin:${var.password}@localhost"
+}`}),
+	}
+
+	flows, err := runner.GetVariableFlows("db")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	got := []string{}
+	for _, flow := range flows {
+		declared := flow.Variable != nil
+		outputs := []string{}
+		for _, output := range flow.Outputs {
+			outputs = append(outputs, output.Name)
+		}
+		got = append(got, fmt.Sprintf("%s: declared=%t, references=%d, outputs=%v", flow.Argument.Name, declared, len(flow.References), outputs))
+	}
+	expected := []string{
+		"engine: declared=true, references=2, outputs=[connection]",
+		"password: declared=true, references=2, outputs=[connection]",
+		"typo: declared=false, references=0, outputs=[]",
+	}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+
+	flows, err = runner.GetVariableFlows("vpc")
+	if err != nil || flows != nil {
+		t.Fatalf("Expected nil for modules not loaded, but got %#v, err=%v", flows, err)
+	}
+}
+
+func Test_WalkAttributeOrder_json(t *testing.T) {
+	src := `{
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "instance_type": "t2.micro",
+        "ami": "ami-1234",
+        "instance_type": "t3.${var.size}"
+      }
+    }
+  }
+}`
+	file, diags := json.Parse([]byte(src), "main.tf.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	runner := &Runner{Files: map[string]*hcl.File{"main.tf.json": file}}
+
+	walked := 0
+	err := runner.WalkAttributeOrder("aws_instance", func(order *tflint.AttributeOrder) error {
+		walked++
+		if !cmp.Equal([]string{"instance_type", "ami"}, order.Names()) {
+			t.Fatalf("Unexpected order: %v", order.Names())
+		}
+		if len(order.Duplicates) != 1 {
+			t.Fatalf("Expected 1 duplicate, but got %d", len(order.Duplicates))
+		}
+
+		duplicate := order.Duplicates[0]
+		if duplicate.Name != "instance_type" {
+			t.Fatalf("Unexpected duplicate: %s", duplicate.Name)
+		}
+		expected := map[string]string{
+			"range":      "main.tf.json:7,9-42",
+			"name_range": "main.tf.json:7,9-24",
+			"expr_range": "main.tf.json:7,26-42",
+		}
+		got := map[string]string{
+			"range":      duplicate.Range.String(),
+			"name_range": duplicate.NameRange.String(),
+			"expr_range": duplicate.Expr.Range().String(),
+		}
+		if !cmp.Equal(expected, got) {
+			t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+		}
+		if vars := duplicate.Expr.Variables(); len(vars) != 1 || vars[0].RootName() != "var" {
+			t.Fatalf("Unexpected variables: %#v", vars)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if walked != 1 {
+		t.Fatalf("Expected 1 resource, but got %d", walked)
+	}
+}
@@ -0,0 +1,93 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// StressRules is a test helper for catching concurrency bugs in rules, such as shared state in rule structs.
+// It runs each rule of the ruleset the passed number of times concurrently against the passed fixture,
+// and fails the test if the results (issues and errors) differ between runs.
+// Each run has its own Runner, so the rule instances are the only state shared between goroutines.
+//
+// Data races are reported only when tests are run with the race detector:
+//
+//	go test -race ./...
+//
+// Example:
+//
+//	func Test_Concurrency(t *testing.T) {
+//		helper.StressRules(t, ruleset, map[string]string{"main.tf": content}, 10)
+//	}
+func StressRules(t *testing.T, ruleset *tflint.RuleSet, files map[string]string, runs int) {
+	if err := stressRules(ruleset.Rules, files, runs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type stressResult struct {
+	issues Issues
+	err    string
+}
+
+func stressRules(rules []tflint.Rule, files map[string]string, runs int) error {
+	if runs < 2 {
+		runs = 2
+	}
+
+	// Runners are prepared before starting goroutines, so that parse errors are reported as usual,
+	// and parsed files are not shared between runs.
+	runners := make([][]*Runner, len(rules))
+	for i := range rules {
+		runners[i] = make([]*Runner, runs)
+		for j := 0; j < runs; j++ {
+			runner, err := newTestRunner(files)
+			if err != nil {
+				return err
+			}
+			runners[i][j] = runner
+		}
+	}
+
+	results := make([][]stressResult, len(rules))
+	var wg sync.WaitGroup
+	for i, rule := range rules {
+		results[i] = make([]stressResult, runs)
+		for j := 0; j < runs; j++ {
+			wg.Add(1)
+			go func(i int, j int, rule tflint.Rule) {
+				defer wg.Done()
+				runner := runners[i][j]
+				if err := rule.Check(runner); err != nil {
+					results[i][j].err = err.Error()
+				}
+				results[i][j].issues = runner.Issues
+			}(i, j, rule)
+		}
+	}
+	wg.Wait()
+
+	opts := []cmp.Option{
+		cmp.AllowUnexported(stressResult{}),
+		cmpopts.IgnoreFields(Issue{}, "Rule"),
+	}
+	failures := []string{}
+	for i, rule := range rules {
+		for j := 1; j < runs; j++ {
+			if !cmp.Equal(results[i][0], results[i][j], opts...) {
+				failures = append(failures, fmt.Sprintf("`%s` rule returned different results in concurrent runs:\n %s", rule.Name(), cmp.Diff(results[i][0], results[i][j], opts...)))
+				break
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
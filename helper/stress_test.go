@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// onceRule reports instances only in the first run because it keeps state in the rule struct.
+type onceRule struct {
+	mu   sync.Mutex
+	seen bool
+}
+
+func (r *onceRule) Name() string     { return "once_rule" }
+func (r *onceRule) Enabled() bool    { return true }
+func (r *onceRule) Severity() string { return tflint.ERROR }
+func (r *onceRule) Link() string     { return "" }
+func (r *onceRule) Check(runner tflint.Runner) error {
+	r.mu.Lock()
+	seen := r.seen
+	r.seen = true
+	r.mu.Unlock()
+	if seen {
+		return nil
+	}
+
+	return runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+		return runner.EmitIssue(r, "instance_type found", attribute.Expr.Range(), tflint.Metadata{})
+	})
+}
+
+// instanceTypeRule reports instances without keeping state.
+type instanceTypeRule struct{}
+
+func (r *instanceTypeRule) Name() string     { return "instance_type_rule" }
+func (r *instanceTypeRule) Enabled() bool    { return true }
+func (r *instanceTypeRule) Severity() string { return tflint.ERROR }
+func (r *instanceTypeRule) Link() string     { return "" }
+func (r *instanceTypeRule) Check(runner tflint.Runner) error {
+	return runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+		return runner.EmitIssue(r, "instance_type found", attribute.Expr.Range(), tflint.Metadata{})
+	})
+}
+
+func Test_stressRules(t *testing.T) {
+	files := map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+}`}
+
+	if err := stressRules([]tflint.Rule{&instanceTypeRule{}}, files, 10); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	err := stressRules([]tflint.Rule{&instanceTypeRule{}, &onceRule{}}, files, 10)
+	if err == nil {
+		t.Fatal("Expected an error, but no error occurred")
+	}
+	if !strings.Contains(err.Error(), "`once_rule` rule returned different results") || strings.Contains(err.Error(), "instance_type_rule") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if err := stressRules([]tflint.Rule{&instanceTypeRule{}}, map[string]string{"main.tf": `resource "aws_instance" {`}, 2); err == nil {
+		t.Fatal("Expected an error, but no error occurred")
+	}
+}
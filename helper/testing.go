@@ -1,7 +1,9 @@
 package helper
 
 import (
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -12,19 +14,32 @@ import (
 )
 
 // TestRunner returns a pseudo Runner for testing
+// Files with the .tfvars extension are treated as variable files.
 func TestRunner(t *testing.T, files map[string]string) *Runner {
-	runner := &Runner{Files: map[string]*hcl.File{}, Issues: Issues{}}
+	runner, err := newTestRunner(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return runner
+}
+
+func newTestRunner(files map[string]string) (*Runner, error) {
+	runner := &Runner{Files: map[string]*hcl.File{}, Issues: Issues{}, VariableFiles: map[string]*hcl.File{}}
 	parser := hclparse.NewParser()
 
 	for name, src := range files {
 		file, diags := parser.ParseHCL([]byte(src), name)
 		if diags.HasErrors() {
-			t.Fatal(diags)
+			return nil, diags
+		}
+		if strings.HasSuffix(name, ".tfvars") {
+			runner.VariableFiles[name] = file
+			continue
 		}
 		runner.Files[name] = file
 	}
 
-	return runner
+	return runner, nil
 }
 
 // AssertIssues is an assertion helper for comparing issues
@@ -57,3 +72,39 @@ func ruleComparer() cmp.Option {
 		return reflect.TypeOf(x) == reflect.TypeOf(y)
 	})
 }
+
+// AssertRuleLinks is an assertion helper for checking that every rule in the ruleset has
+// a unique, absolute HTTP(S) documentation link.
+func AssertRuleLinks(t *testing.T, ruleset *tflint.RuleSet) {
+	seen := map[string]string{}
+	for _, rule := range ruleset.Rules {
+		link := ruleset.RuleLink(rule)
+		if link == "" {
+			t.Errorf("`%s` rule has no link", rule.Name())
+			continue
+		}
+
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			t.Errorf("`%s` rule has an invalid link: %s", rule.Name(), link)
+			continue
+		}
+		if strings.Contains(link, "{{") {
+			t.Errorf("`%s` rule has an unresolved placeholder in the link: %s", rule.Name(), link)
+			continue
+		}
+
+		if other, ok := seen[link]; ok {
+			t.Errorf("`%s` rule has the same link as `%s` rule: %s", rule.Name(), other, link)
+			continue
+		}
+		seen[link] = rule.Name()
+	}
+}
+
+// AssertRuleSet is an assertion helper for checking that the ruleset passes the validation run by plugin.Serve
+func AssertRuleSet(t *testing.T, ruleset *tflint.RuleSet) {
+	if err := ruleset.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,214 @@
+// Package httpclient provides an HTTP client for rules that consult external services (e.g. CMDBs, allow-list APIs).
+// It applies timeouts, retries and response caching consistently so that such integrations do not stall lint runs.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Options is an option for the client
+type Options struct {
+	// Timeout is the timeout per request. Default is 10 seconds.
+	Timeout time.Duration
+	// Retries is the number of retries on network errors, 429 and 5xx responses. Default is 2.
+	// Set a negative value to disable retries.
+	Retries int
+	// RetryWait is the wait before the first retry. It doubles on each retry. Default is 1 second.
+	RetryWait time.Duration
+	// Offline makes all requests fail with a warning-level error without sending them.
+	// Usually pass Runner.IsOffline().
+	Offline bool
+}
+
+// Client is an HTTP client with timeouts, retries and response caching.
+// It is safe for concurrent use.
+type Client struct {
+	http    *http.Client
+	opts    Options
+	mu      sync.Mutex
+	cache   map[string][]byte
+	sleeper func(context.Context, time.Duration) error
+}
+
+// New returns a new client
+func New(opts Options) *Client {
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Retries == 0 {
+		opts.Retries = 2
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.RetryWait == 0 {
+		opts.RetryWait = time.Second
+	}
+
+	return &Client{
+		http:    &http.Client{Timeout: opts.Timeout},
+		opts:    opts,
+		cache:   map[string][]byte{},
+		sleeper: sleep,
+	}
+}
+
+// sleep waits for the duration, or returns the error of the context as soon as it is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CacheKey returns a hash of the passed values encoded in JSON.
+// Use the values that determine the response (e.g. evaluated configuration) as the key.
+func CacheKey(values ...interface{}) (string, error) {
+	src, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(src)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Get is the same as GetContext with context.Background().
+func (c *Client) Get(url string) ([]byte, error) {
+	return c.GetContext(context.Background(), url)
+}
+
+// GetContext sends a GET request and returns the response body.
+// Responses are cached by the URL during the lifetime of the client.
+// Pass Runner.Context() to stop requests and retries when the rule finishes.
+func (c *Client) GetContext(ctx context.Context, url string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, url, nil, "GET "+url)
+}
+
+// PostJSON is the same as PostJSONContext with context.Background().
+func (c *Client) PostJSON(url string, body interface{}, ret interface{}) error {
+	return c.PostJSONContext(context.Background(), url, body, ret)
+}
+
+// PostJSONContext sends the passed body as JSON and decodes the response JSON into ret.
+// Responses are cached by the URL and the hash of the body during the lifetime of the client.
+func (c *Client) PostJSONContext(ctx context.Context, url string, body interface{}, ret interface{}) error {
+	src, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	key, err := CacheKey(url, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, url, src, "POST "+key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp, ret); err != nil {
+		return tflint.Error{
+			Code:    tflint.ExternalAPIError,
+			Level:   tflint.ErrorLevel,
+			Message: fmt.Sprintf("Failed to decode the response from %s", url),
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, url string, body []byte, key string) ([]byte, error) {
+	if c.opts.Offline {
+		return nil, tflint.Error{
+			Code:    tflint.ExternalAPIError,
+			Level:   tflint.WarningLevel,
+			Message: fmt.Sprintf("Skipped %s %s in offline mode", method, url),
+		}
+	}
+
+	c.mu.Lock()
+	cached, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok {
+		log.Printf("[DEBUG] Cache hit: %s %s", method, url)
+		// Return a copy so that callers modifying the response do not corrupt the cache.
+		return append([]byte(nil), cached...), nil
+	}
+
+	var lastErr error
+	wait := c.opts.RetryWait
+	for attempt := 0; attempt <= c.opts.Retries; attempt++ {
+		if attempt > 0 {
+			log.Printf("[DEBUG] Retry %s %s after %s: %s", method, url, wait, lastErr)
+			if err := c.sleeper(ctx, wait); err != nil {
+				lastErr = err
+				break
+			}
+			wait *= 2
+		}
+
+		resp, retryable, err := c.send(ctx, method, url, body)
+		if err == nil {
+			c.mu.Lock()
+			c.cache[key] = append([]byte(nil), resp...)
+			c.mu.Unlock()
+			return resp, nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+
+	return nil, tflint.Error{
+		Code:    tflint.ExternalAPIError,
+		Level:   tflint.ErrorLevel,
+		Message: fmt.Sprintf("Failed to request %s %s", method, url),
+		Cause:   lastErr,
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, url string, body []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	src, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, true, fmt.Errorf("unexpected status: %s", resp.Status)
+	case resp.StatusCode >= 400:
+		return nil, false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return src, false, nil
+}
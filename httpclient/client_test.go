@@ -0,0 +1,138 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_PostJSON(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		json.NewEncoder(w).Encode(map[string]bool{"allowed": body["name"] == "web"})
+	}))
+	defer server.Close()
+
+	client := New(Options{})
+	client.sleeper = func(context.Context, time.Duration) error { return nil }
+
+	for i := 0; i < 2; i++ {
+		var resp map[string]bool
+		if err := client.PostJSON(server.URL, map[string]string{"name": "web"}, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if !resp["allowed"] {
+			t.Fatalf("Unexpected response: %#v", resp)
+		}
+	}
+
+	// The first call is retried, and the second request is served from the cache
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls, but got %d", calls)
+	}
+}
+
+func Test_Get_errors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := New(Options{})
+	client.sleeper = func(context.Context, time.Duration) error { return nil }
+
+	_, err := client.Get(server.URL)
+	if appErr, ok := err.(tflint.Error); !ok || appErr.Level != tflint.ErrorLevel {
+		t.Fatalf("Expected an error-level error, but got %#v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call without retries, but got %d", calls)
+	}
+
+	offline := New(Options{Offline: true})
+	_, err = offline.Get(server.URL)
+	if appErr, ok := err.(tflint.Error); !ok || appErr.Level != tflint.WarningLevel {
+		t.Fatalf("Expected a warning-level error, but got %#v", err)
+	}
+}
+
+func Test_GetContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// The retry wait must be interrupted by the cancellation.
+	client := New(Options{RetryWait: time.Hour})
+
+	_, err := client.GetContext(ctx, server.URL)
+	if appErr, ok := err.(tflint.Error); !ok || appErr.Cause != context.Canceled {
+		t.Fatalf("Expected a canceled error, but got %#v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call without retries, but got %d", calls)
+	}
+
+	// Requests are not sent with the canceled context.
+	_, err = client.GetContext(ctx, server.URL)
+	if err == nil || calls != 1 {
+		t.Fatalf("Expected the request not to be sent, but got %d calls, err=%#v", calls, err)
+	}
+}
+
+func Test_Get_cache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("allowed"))
+	}))
+	defer server.Close()
+
+	client := New(Options{})
+
+	first, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0] = 'X'
+
+	second, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second[1] = 'X'
+
+	third, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(third) != "allowed" {
+		t.Fatalf("Expected the cached response not to be modified, but got %q", third)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, but got %d", calls)
+	}
+}
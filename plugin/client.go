@@ -8,6 +8,8 @@ import (
 	"github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 )
 
 // Client is an RPC client for use by the host
@@ -58,16 +60,62 @@ func (c *Client) RuleNames() ([]string, error) {
 	return resp, err
 }
 
+// RuleDocumentation queries the RPC server for RuleDocumentation
+func (c *Client) RuleDocumentation(name string) (string, error) {
+	var resp string
+	err := c.rpcClient.Call("Plugin.RuleDocumentation", name, &resp)
+	return resp, err
+}
+
+// MessageCatalog queries the RPC server for MessageCatalog
+func (c *Client) MessageCatalog() (tflint.MessageCatalog, error) {
+	var resp tflint.MessageCatalog
+	err := c.rpcClient.Call("Plugin.MessageCatalog", new(interface{}), &resp)
+	return resp, err
+}
+
 // ApplyConfig queries the RPC server for ApplyConfig
 func (c *Client) ApplyConfig(config *tflint.Config) error {
 	return c.rpcClient.Call("Plugin.ApplyConfig", config, new(interface{}))
 }
 
+// Functions queries the RPC server for custom functions and returns them namespaced with the ruleset name
+// Calls to the returned functions are forwarded to the plugin, so add them to the evaluation context of the host.
+func (c *Client) Functions() (map[string]function.Function, error) {
+	name, err := c.RuleSetName()
+	if err != nil {
+		return nil, err
+	}
+	var signatures []*tflint.FunctionSignature
+	if err := c.rpcClient.Call("Plugin.FunctionSignatures", new(interface{}), &signatures); err != nil {
+		return nil, err
+	}
+
+	functions := map[string]function.Function{}
+	for _, signature := range signatures {
+		fnName := signature.Name
+		functions[tflint.FunctionName(name, fnName)] = tflint.NewRemoteFunction(signature, func(args []cty.Value) (cty.Value, error) {
+			var resp cty.Value
+			err := c.rpcClient.Call("Plugin.CallFunction", &tflint.CallFunctionRequest{Name: fnName, Args: args}, &resp)
+			return resp, err
+		})
+	}
+	return functions, nil
+}
+
 // Check queries the RPC server for Check
 // For bi-directional communication, you can pass a server that accepts Runner's queries
+// If some rules failed, tflint.RuleErrors is returned so that the host can report all of them.
 func (c *Client) Check(server tflint.Server) error {
 	brokerID := c.broker.NextId()
 	go c.broker.AcceptAndServe(brokerID, server)
 
-	return c.rpcClient.Call("Plugin.Check", brokerID, new(interface{}))
+	var resp interface{}
+	if err := c.rpcClient.Call("Plugin.Check", brokerID, &resp); err != nil {
+		return err
+	}
+	if err, ok := resp.(error); ok {
+		return err
+	}
+	return nil
 }
@@ -1,8 +1,12 @@
 package plugin
 
 import (
+	"fmt"
+	"os"
+
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Server is an RPC server acting as a plugin
@@ -15,10 +19,34 @@ type Server struct {
 // Each plugin can pass a RuleSet that represents its own functionality
 type ServeOpts struct {
 	RuleSet tflint.RuleSet
+	// Limits enables the watchdog that aborts the plugin when the limits are exceeded. Optional.
+	Limits *Limits
+	// StrictValidation makes the plugin exit if the ruleset is invalid. By default, problems are only logged as warnings
+	// so that existing plugins keep working. Use helper.AssertRuleSet to catch them in tests.
+	StrictValidation bool
 }
 
 // Serve is a wrapper of plugin.Serve. This is entrypoint of all plugins
+// The ruleset and the registration of types sent via RPC are validated before serving.
+// The plugin exits with a descriptive error if the types are invalid. Problems with the ruleset are logged as warnings,
+// or the plugin exits if StrictValidation is set.
 func Serve(opts *ServeOpts) {
+	tflint.RegisterWireTypes()
+	if err := tflint.CheckWireTypes(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := opts.RuleSet.Validate(); err != nil {
+		if opts.StrictValidation {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "[WARN] %s\n", err)
+	}
+	if opts.Limits != nil {
+		startWatchdog(*opts.Limits, opts.RuleSet.TrackProgress())
+	}
+
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: handshakeConfig,
 		Plugins: map[string]plugin.Plugin{
@@ -45,18 +73,49 @@ func (s *Server) RuleNames(args interface{}, resp *[]string) error {
 	return nil
 }
 
+// RuleDocumentation replies the documentation of the rule with the passed name
+func (s *Server) RuleDocumentation(name string, resp *string) error {
+	doc, err := s.impl.RuleDocumentation(name)
+	*resp = doc
+	return err
+}
+
+// MessageCatalog replies its own the result of MessageCatalog
+func (s *Server) MessageCatalog(args interface{}, resp *tflint.MessageCatalog) error {
+	*resp = s.impl.MessageCatalog()
+	return nil
+}
+
 // ApplyConfig applies the passed config to its own plugin implementation
 func (s *Server) ApplyConfig(config *tflint.Config, resp *interface{}) error {
-	s.impl.ApplyConfig(config)
+	return s.impl.ApplyConfig(config)
+}
+
+// FunctionSignatures replies the signatures of its own custom functions
+func (s *Server) FunctionSignatures(args interface{}, resp *[]*tflint.FunctionSignature) error {
+	*resp = s.impl.FunctionSignatures()
 	return nil
 }
 
+// CallFunction calls its own custom function and replies the result
+func (s *Server) CallFunction(req *tflint.CallFunctionRequest, resp *cty.Value) error {
+	val, err := s.impl.CallFunction(req.Name, req.Args)
+	*resp = val
+	return err
+}
+
 // Check initializes an RPC client that can query to the host process and pass it to the Check method
+// RuleErrors are replied as a response instead of an error because net/rpc sends only the message of errors.
 func (s *Server) Check(brokerID uint32, resp *interface{}) error {
 	conn, err := s.broker.Dial(brokerID)
 	if err != nil {
 		return err
 	}
 
-	return s.impl.Check(tflint.NewClient(conn))
+	err = s.impl.Check(tflint.NewClient(conn))
+	if errs, ok := err.(tflint.RuleErrors); ok {
+		*resp = errs
+		return nil
+	}
+	return err
 }
@@ -0,0 +1,92 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Limits is the resource limits of the plugin process monitored by the watchdog.
+// When a limit is exceeded, the plugin aborts with a diagnostic reporting the rule being checked,
+// instead of being killed silently (e.g. by the OOM killer).
+type Limits struct {
+	// MaxMemory is the maximum heap size in bytes. 0 means no limit.
+	MaxMemory uint64
+	// MaxRuleDuration is the maximum time to check a rule. 0 means no limit.
+	// Since CPU time cannot be measured portably, the wall-clock time is used instead.
+	MaxRuleDuration time.Duration
+	// Interval is the interval of monitoring. Default is 1 second.
+	Interval time.Duration
+}
+
+type watchdog struct {
+	limits Limits
+	// current returns the rule being checked and the time it started (see tflint.Progress.Current).
+	current func() (string, time.Time)
+	abort   func(error)
+}
+
+// startWatchdog starts monitoring in the background
+func startWatchdog(limits Limits, progress *tflint.Progress) {
+	if limits.Interval == 0 {
+		limits.Interval = time.Second
+	}
+
+	w := &watchdog{
+		limits:  limits,
+		current: progress.Current,
+		abort: func(err error) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		},
+	}
+	go w.run()
+}
+
+func (w *watchdog) run() {
+	ticker := time.NewTicker(w.limits.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := w.check(); err != nil {
+			w.abort(err)
+			return
+		}
+	}
+}
+
+func (w *watchdog) check() error {
+	rule, started := w.current()
+	if rule == "" {
+		rule = "(none)"
+	}
+
+	if w.limits.MaxMemory > 0 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > w.limits.MaxMemory {
+			return fmt.Errorf(
+				"Plugin aborted: heap size %d MiB exceeded the limit %d MiB while checking `%s` rule",
+				stats.HeapAlloc/1024/1024,
+				w.limits.MaxMemory/1024/1024,
+				rule,
+			)
+		}
+	}
+
+	if w.limits.MaxRuleDuration > 0 && !started.IsZero() {
+		if elapsed := time.Since(started); elapsed > w.limits.MaxRuleDuration {
+			return fmt.Errorf(
+				"Plugin aborted: checking `%s` rule took %s, exceeding the limit %s",
+				rule,
+				elapsed.Round(time.Second),
+				w.limits.MaxRuleDuration,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package plugin
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_watchdog_check(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Limits   Limits
+		Rule     string
+		Elapsed  time.Duration
+		Expected string
+	}{
+		{
+			Name:     "heap exceeded",
+			Limits:   Limits{MaxMemory: 1},
+			Rule:     "aws_instance_invalid_type",
+			Elapsed:  time.Second,
+			Expected: "^Plugin aborted: heap size \\d+ MiB exceeded the limit 0 MiB while checking `aws_instance_invalid_type` rule$",
+		},
+		{
+			Name:     "heap exceeded without rules",
+			Limits:   Limits{MaxMemory: 1},
+			Expected: "^Plugin aborted: heap size \\d+ MiB exceeded the limit 0 MiB while checking `\\(none\\)` rule$",
+		},
+		{
+			Name:    "heap within the limit",
+			Limits:  Limits{MaxMemory: 1 << 40},
+			Rule:    "aws_instance_invalid_type",
+			Elapsed: time.Second,
+		},
+		{
+			Name:     "rule duration exceeded",
+			Limits:   Limits{MaxRuleDuration: time.Minute},
+			Rule:     "aws_instance_invalid_type",
+			Elapsed:  2 * time.Minute,
+			Expected: "^Plugin aborted: checking `aws_instance_invalid_type` rule took 2m0s, exceeding the limit 1m0s$",
+		},
+		{
+			Name:    "rule duration within the limit",
+			Limits:  Limits{MaxRuleDuration: time.Minute},
+			Rule:    "aws_instance_invalid_type",
+			Elapsed: time.Second,
+		},
+		{
+			Name:   "no rule running",
+			Limits: Limits{MaxRuleDuration: time.Nanosecond},
+		},
+		{
+			Name:    "zero limits",
+			Limits:  Limits{},
+			Rule:    "aws_instance_invalid_type",
+			Elapsed: 24 * time.Hour,
+		},
+	}
+
+	for _, tc := range cases {
+		w := &watchdog{
+			limits: tc.Limits,
+			current: func() (string, time.Time) {
+				if tc.Rule == "" {
+					return "", time.Time{}
+				}
+				return tc.Rule, time.Now().Add(-tc.Elapsed)
+			},
+		}
+
+		err := w.check()
+		if tc.Expected == "" {
+			if err != nil {
+				t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, err)
+			}
+			continue
+		}
+		if err == nil || !regexp.MustCompile(tc.Expected).MatchString(err.Error()) {
+			t.Fatalf("Failed `%s` test: expected an error matching `%s`, but got %v", tc.Name, tc.Expected, err)
+		}
+	}
+}
+
+func Test_watchdog_run(t *testing.T) {
+	aborted := make(chan error, 1)
+	w := &watchdog{
+		limits:  Limits{MaxRuleDuration: time.Millisecond, Interval: time.Millisecond},
+		current: func() (string, time.Time) { return "slow_rule", time.Now().Add(-time.Second) },
+		abort:   func(err error) { aborted <- err },
+	}
+	go w.run()
+
+	select {
+	case err := <-aborted:
+		if err == nil || !regexp.MustCompile("`slow_rule` rule").MatchString(err.Error()) {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The watchdog did not abort")
+	}
+}
@@ -0,0 +1,152 @@
+// Package policy bridges policy engines (e.g. OPA/Rego) and TFLint rules.
+// A policy rule fetches resources via the Runner, converts them into a plain JSON-compatible input document,
+// passes it to an Evaluator, and emits issues from the returned denials.
+//
+// The SDK does not depend on any policy engine. Implement Evaluator with the engine of your choice, for example:
+//
+//	type regoEvaluator struct {
+//		query rego.PreparedEvalQuery
+//	}
+//
+//	func (e *regoEvaluator) Evaluate(input map[string]interface{}) ([]policy.Denial, error) {
+//		results, err := e.query.Eval(context.Background(), rego.EvalInput(input))
+//		if err != nil {
+//			return nil, err
+//		}
+//		denials := []policy.Denial{}
+//		for _, result := range results {
+//			for _, deny := range result.Expressions[0].Value.([]interface{}) {
+//				d := deny.(map[string]interface{})
+//				denials = append(denials, policy.Denial{Message: d["msg"].(string), Resource: d["resource"].(string)})
+//			}
+//		}
+//		return denials, nil
+//	}
+package policy
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Evaluator evaluates policies against the input document and returns denials.
+//
+// The input document is converted by tflint.ContentToMap and has the following shape.
+// Attribute values that cannot be evaluated (e.g. unknown) are null.
+//
+//	{"resource": {"aws_instance": {"web": {"instance_type": "t2.micro"}}}}
+type Evaluator interface {
+	Evaluate(input map[string]interface{}) ([]Denial, error)
+}
+
+// Denial is a policy violation returned by Evaluator.
+type Denial struct {
+	Message string
+	// Resource is the address of the denied resource (e.g. aws_instance.web).
+	// The issue is reported at the resource's declaration. If empty, the issue has no location.
+	Resource string
+}
+
+// RuleSpec is a specification of a rule backed by policies.
+type RuleSpec struct {
+	// Name is the rule name.
+	Name string
+	// ResourceTypes is the list of resource types included in the input. If empty, all resources are included.
+	ResourceTypes []string
+	// Evaluator evaluates policies.
+	Evaluator Evaluator
+	// Severity is the rule severity. If empty, tflint.ERROR is used.
+	Severity string
+	// Link is the rule reference link.
+	Link string
+	// DisabledByDefault disables the rule unless it is enabled by the config.
+	DisabledByDefault bool
+}
+
+// Rule is a rule built from RuleSpec
+type Rule struct {
+	spec *RuleSpec
+}
+
+// NewRule returns a new rule built from the passed spec
+func NewRule(spec *RuleSpec) *Rule {
+	return &Rule{spec: spec}
+}
+
+// Name returns the rule name
+func (r *Rule) Name() string {
+	return r.spec.Name
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *Rule) Enabled() bool {
+	return !r.spec.DisabledByDefault
+}
+
+// Severity returns the rule severity
+func (r *Rule) Severity() string {
+	if r.spec.Severity == "" {
+		return tflint.ERROR
+	}
+	return r.spec.Severity
+}
+
+// Link returns the rule reference link
+func (r *Rule) Link() string {
+	return r.spec.Link
+}
+
+// Check evaluates policies against resources and emits issues from denials
+func (r *Rule) Check(runner tflint.Runner) error {
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	ranges := map[string]hcl.Range{}
+	blocks := hcl.Blocks{}
+	for _, block := range content.Blocks {
+		resourceType, name := block.Labels[0], block.Labels[1]
+		if len(r.spec.ResourceTypes) > 0 && !contains(r.spec.ResourceTypes, resourceType) {
+			continue
+		}
+		blocks = append(blocks, block)
+		ranges[resourceType+"."+name] = block.DefRange
+	}
+
+	input, err := tflint.ContentToMap(runner, &hcl.BodyContent{Blocks: blocks})
+	if err != nil {
+		return err
+	}
+	if _, exists := input["resource"]; !exists {
+		input["resource"] = map[string]interface{}{}
+	}
+
+	denials, err := r.spec.Evaluator.Evaluate(input)
+	if err != nil {
+		return err
+	}
+
+	for _, denial := range denials {
+		if err := runner.EmitIssue(r, denial.Message, ranges[denial.Resource], tflint.Metadata{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+type evaluatorFunc func(map[string]interface{}) ([]Denial, error)
+
+func (f evaluatorFunc) Evaluate(input map[string]interface{}) ([]Denial, error) { return f(input) }
+
+func Test_Rule(t *testing.T) {
+	// A policy equivalent to:
+	//
+	//   deny[{"msg": msg, "resource": resource}] {
+	//     instance := input.resource.aws_instance[name]
+	//     instance.instance_type != "t2.micro"
+	//     resource := sprintf("aws_instance.%s", [name])
+	//     msg := sprintf("%s is not allowed", [instance.instance_type])
+	//   }
+	evaluator := evaluatorFunc(func(input map[string]interface{}) ([]Denial, error) {
+		denials := []Denial{}
+		instances := input["resource"].(map[string]interface{})["aws_instance"].(map[string]interface{})
+		for name, instance := range instances {
+			instanceType := instance.(map[string]interface{})["instance_type"]
+			if instanceType != "t2.micro" {
+				denials = append(denials, Denial{
+					Message:  fmt.Sprintf("%v is not allowed", instanceType),
+					Resource: "aws_instance." + name,
+				})
+			}
+		}
+		return denials, nil
+	})
+
+	rule := NewRule(&RuleSpec{
+		Name:          "aws_instance_policy",
+		ResourceTypes: []string{"aws_instance"},
+		Evaluator:     evaluator,
+	})
+
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  instance_type = "m5.large"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}`})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatal(err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "m5.large is not allowed",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1},
+				End:      hcl.Pos{Line: 2, Column: 30},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,307 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// ConstraintRuleSpec is a declarative specification of a rule that checks resources against constraints.
+// It can be loaded from JSON, so platform teams can maintain policies without Go code.
+//
+//	{
+//	  "name": "aws_instance_policy",
+//	  "resources": [
+//	    {
+//	      "type": "aws_instance",
+//	      "required": ["tags"],
+//	      "forbidden": ["associate_public_ip_address"],
+//	      "attributes": [
+//	        {"name": "instance_type", "one_of": ["t3.micro", "t3.small"]},
+//	        {"name": "volume_size", "min": 8, "max": 100}
+//	      ]
+//	    }
+//	  ]
+//	}
+type ConstraintRuleSpec struct {
+	// Name is the rule name.
+	Name string `json:"name"`
+	// Resources is the list of constraints per resource type.
+	Resources []ResourceConstraint `json:"resources"`
+	// Severity is the rule severity. If empty, tflint.ERROR is used.
+	Severity string `json:"severity"`
+	// Link is the rule reference link.
+	Link string `json:"link"`
+	// DisabledByDefault disables the rule unless it is enabled by the config.
+	DisabledByDefault bool `json:"disabled_by_default"`
+}
+
+// ResourceConstraint is the constraints on resources of a type.
+type ResourceConstraint struct {
+	Type string `json:"type"`
+	// Required is the list of attributes that must be set.
+	Required []string `json:"required"`
+	// Forbidden is the list of attributes that must not be set.
+	Forbidden []string `json:"forbidden"`
+	// Attributes is the list of constraints on attribute values.
+	Attributes []AttributeConstraint `json:"attributes"`
+}
+
+// AttributeConstraint is the constraints on an attribute value. Only the set constraints are checked.
+type AttributeConstraint struct {
+	Name string `json:"name"`
+	// OneOf is the list of allowed values.
+	OneOf []string `json:"one_of"`
+	// Pattern is the regular expression that the value must match.
+	Pattern string `json:"pattern"`
+	// Min and Max are the range of allowed numbers.
+	Min *float64 `json:"min"`
+	Max *float64 `json:"max"`
+}
+
+// ParseConstraintRuleSpecs parses a JSON array of ConstraintRuleSpec.
+func ParseConstraintRuleSpecs(src []byte) ([]*ConstraintRuleSpec, error) {
+	var specs []*ConstraintRuleSpec
+	if err := json.Unmarshal(src, &specs); err != nil {
+		return nil, fmt.Errorf("Failed to parse constraints: %s", err)
+	}
+	return specs, nil
+}
+
+// ConstraintRule is a rule built from ConstraintRuleSpec
+type ConstraintRule struct {
+	spec     *ConstraintRuleSpec
+	patterns map[string]*regexp.Regexp
+}
+
+// NewConstraintRule compiles the passed spec into a rule.
+// Unlike other factories, it returns an error instead of panicking, because specs are usually loaded at runtime.
+func NewConstraintRule(spec *ConstraintRuleSpec) (*ConstraintRule, error) {
+	if spec.Name == "" {
+		return nil, fmt.Errorf("Constraint rule name is empty")
+	}
+
+	patterns := map[string]*regexp.Regexp{}
+	for _, resource := range spec.Resources {
+		if resource.Type == "" {
+			return nil, fmt.Errorf("`%s` rule has a constraint with an empty resource type", spec.Name)
+		}
+		for _, attribute := range resource.Attributes {
+			if attribute.Min != nil && attribute.Max != nil && *attribute.Min > *attribute.Max {
+				return nil, fmt.Errorf("`%s` rule has an invalid range for `%s.%s`", spec.Name, resource.Type, attribute.Name)
+			}
+			if attribute.Pattern == "" {
+				continue
+			}
+			pattern, err := regexp.Compile(attribute.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("`%s` rule has an invalid pattern for `%s.%s`: %s", spec.Name, resource.Type, attribute.Name, err)
+			}
+			patterns[attribute.Pattern] = pattern
+		}
+	}
+
+	return &ConstraintRule{spec: spec, patterns: patterns}, nil
+}
+
+// NewConstraintRules compiles the passed specs into rules.
+func NewConstraintRules(specs []*ConstraintRuleSpec) ([]tflint.Rule, error) {
+	rules := make([]tflint.Rule, len(specs))
+	for i, spec := range specs {
+		rule, err := NewConstraintRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// Name returns the rule name
+func (r *ConstraintRule) Name() string {
+	return r.spec.Name
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *ConstraintRule) Enabled() bool {
+	return !r.spec.DisabledByDefault
+}
+
+// Severity returns the rule severity
+func (r *ConstraintRule) Severity() string {
+	if r.spec.Severity == "" {
+		return tflint.ERROR
+	}
+	return r.spec.Severity
+}
+
+// Link returns the rule reference link
+func (r *ConstraintRule) Link() string {
+	return r.spec.Link
+}
+
+// ApplyConfig overrides the constraints by the rule config, so policies can change without recompiling the plugin.
+// The constraints are a JSON array of ResourceConstraint, passed inline as `policy` or as a file path as `policy_file`.
+//
+//	rule "aws_instance_policy" {
+//	  enabled     = true
+//	  policy_file = "policies/aws_instance.json"
+//	}
+func (r *ConstraintRule) ApplyConfig(config *tflint.RuleConfig) error {
+	policy, hasPolicy := config.Options["policy"]
+	policyFile, hasPolicyFile := config.Options["policy_file"]
+
+	var src []byte
+	switch {
+	case hasPolicy && hasPolicyFile:
+		return fmt.Errorf("`policy` and `policy_file` cannot be set at the same time")
+	case hasPolicy:
+		policy, err := stringOption("policy", policy)
+		if err != nil {
+			return err
+		}
+		src = []byte(policy)
+	case hasPolicyFile:
+		path, err := stringOption("policy_file", policyFile)
+		if err != nil {
+			return err
+		}
+		src, err = ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Failed to read the policy file: %s", err)
+		}
+	default:
+		return nil
+	}
+
+	var resources []ResourceConstraint
+	if err := json.Unmarshal(src, &resources); err != nil {
+		return fmt.Errorf("Failed to parse the policy: %s", err)
+	}
+	spec := *r.spec
+	spec.Resources = resources
+	compiled, err := NewConstraintRule(&spec)
+	if err != nil {
+		return err
+	}
+	*r = *compiled
+	return nil
+}
+
+// Check checks whether resources satisfy the constraints
+func (r *ConstraintRule) Check(runner tflint.Runner) error {
+	for _, resource := range r.spec.Resources {
+		if err := r.checkPresence(runner, resource); err != nil {
+			return err
+		}
+		for _, attribute := range resource.Attributes {
+			if err := r.checkValue(runner, resource.Type, attribute); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *ConstraintRule) checkPresence(runner tflint.Runner, resource ResourceConstraint) error {
+	if len(resource.Required) > 0 {
+		resources, err := runner.GetResourceAttributeNames(resource.Type)
+		if err != nil {
+			return err
+		}
+		for _, names := range resources {
+			for _, required := range resource.Required {
+				if names.Has(required) {
+					continue
+				}
+				err := runner.EmitIssue(r, fmt.Sprintf("`%s` must be set", required), names.DeclRange, tflint.Metadata{})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, forbidden := range resource.Forbidden {
+		err := runner.WalkResourceAttributes(resource.Type, forbidden, func(attribute *hcl.Attribute) error {
+			return runner.EmitIssue(r, fmt.Sprintf("`%s` must not be set", forbidden), attribute.Range, tflint.Metadata{})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ConstraintRule) checkValue(runner tflint.Runner, resourceType string, constraint AttributeConstraint) error {
+	return runner.WalkResourceAttributes(resourceType, constraint.Name, func(attribute *hcl.Attribute) error {
+		if constraint.Min != nil || constraint.Max != nil {
+			var val float64
+			err := runner.EvaluateExpr(attribute.Expr, &val)
+			err = runner.EnsureNoError(err, func() error {
+				if (constraint.Min != nil && val < *constraint.Min) || (constraint.Max != nil && val > *constraint.Max) {
+					return r.emitValueIssue(runner, attribute, tflint.FormatValue(runner, attribute.Expr, val)+" is out of range "+formatRange(constraint))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(constraint.OneOf) == 0 && constraint.Pattern == "" {
+			return nil
+		}
+		var val string
+		err := runner.EvaluateExpr(attribute.Expr, &val)
+		return runner.EnsureNoError(err, func() error {
+			formatted := tflint.FormatValue(runner, attribute.Expr, val)
+			if len(constraint.OneOf) > 0 && !contains(constraint.OneOf, val) {
+				return r.emitValueIssue(runner, attribute, fmt.Sprintf("%s must be one of %s", formatted, quoteJoin(constraint.OneOf)))
+			}
+			if constraint.Pattern != "" && !r.patterns[constraint.Pattern].MatchString(val) {
+				return r.emitValueIssue(runner, attribute, fmt.Sprintf("%s does not match %s", formatted, constraint.Pattern))
+			}
+			return nil
+		})
+	})
+}
+
+func (r *ConstraintRule) emitValueIssue(runner tflint.Runner, attribute *hcl.Attribute, message string) error {
+	return runner.EmitIssue(r, message, attribute.Expr.Range(), tflint.Metadata{Expr: attribute.Expr})
+}
+
+func stringOption(name string, val cty.Value) (string, error) {
+	var ret string
+	if err := gocty.FromCtyValue(val, &ret); err != nil {
+		return "", fmt.Errorf("`%s` must be a string: %s", name, err)
+	}
+	return ret, nil
+}
+
+func formatRange(constraint AttributeConstraint) string {
+	bounds := []string{}
+	if constraint.Min != nil {
+		bounds = append(bounds, fmt.Sprintf(">= %v", *constraint.Min))
+	}
+	if constraint.Max != nil {
+		bounds = append(bounds, fmt.Sprintf("<= %v", *constraint.Max))
+	}
+	return strings.Join(bounds, " and ")
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_ConstraintRule(t *testing.T) {
+	specs, err := ParseConstraintRuleSpecs([]byte(`[
+  {
+    "name": "aws_instance_policy",
+    "resources": [
+      {
+        "type": "aws_instance",
+        "required": ["tags"],
+        "forbidden": ["associate_public_ip_address"],
+        "attributes": [
+          {"name": "instance_type", "one_of": ["t3.micro", "t3.small"]},
+          {"name": "ami", "pattern": "^ami-[0-9a-f]+$"},
+          {"name": "volume_size", "min": 8, "max": 100}
+        ]
+      }
+    ]
+  }
+]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := NewConstraintRules(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := rules[0]
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "valid",
+			Content: `
+resource "aws_instance" "web" {
+  instance_type = "t3.micro"
+  ami           = "ami-0123abcd"
+  volume_size   = 20
+  tags          = {}
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "invalid",
+			Content: `
+resource "aws_instance" "web" {
+  instance_type               = "t2.micro"
+  ami                         = "image"
+  volume_size                 = 200
+  associate_public_ip_address = true
+}`,
+			Expected: helper.Issues{
+				{Rule: rule, Message: "`tags` must be set"},
+				{Rule: rule, Message: "`associate_public_ip_address` must not be set"},
+				{Rule: rule, Message: `"t2.micro" must be one of "t3.micro", "t3.small"`},
+				{Rule: rule, Message: `"image" does not match ^ami-[0-9a-f]+$`},
+				{Rule: rule, Message: "200 is out of range >= 8 and <= 100"},
+			},
+		},
+		{
+			Name: "unknown",
+			Content: `
+variable "type" {}
+
+resource "aws_instance" "web" {
+  instance_type = var.type
+  tags          = {}
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+		helper.AssertIssuesWithoutRange(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_NewConstraintRule_invalid(t *testing.T) {
+	_, err := NewConstraintRule(&ConstraintRuleSpec{
+		Name: "invalid",
+		Resources: []ResourceConstraint{
+			{Type: "aws_instance", Attributes: []AttributeConstraint{{Name: "ami", Pattern: "("}}},
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+}
+
+func Test_ConstraintRule_ApplyConfig(t *testing.T) {
+	rule, err := NewConstraintRule(&ConstraintRuleSpec{
+		Name:      "aws_instance_policy",
+		Resources: []ResourceConstraint{{Type: "aws_instance", Required: []string{"tags"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(`
+policy = <<EOF
+[{"type": "aws_instance", "forbidden": ["user_data"]}]
+EOF
+`), ".tflint.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	options, diags := tflint.DecodeRuleOptions(file.Body, nil)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if err := rule.ApplyConfig(&tflint.RuleConfig{Name: rule.Name(), Enabled: true, Options: options}); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  user_data = "echo"
+}`})
+	if err := rule.Check(runner); err != nil {
+		t.Fatal(err)
+	}
+	helper.AssertIssuesWithoutRange(t, helper.Issues{{Rule: rule, Message: "`user_data` must not be set"}}, runner.Issues)
+}
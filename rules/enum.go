@@ -0,0 +1,99 @@
+// Package rules provides factories that build common rules from declarative specifications.
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// EnumRuleSpec is a declarative specification of a rule that checks whether an attribute value is one of the allowed values.
+type EnumRuleSpec struct {
+	// Name is the rule name (e.g. aws_instance_invalid_type).
+	Name string
+	// ResourceType and AttributeName are the target attribute (e.g. aws_instance and instance_type).
+	ResourceType  string
+	AttributeName string
+	// Values is the list of allowed values.
+	Values []string
+	// Message is a format of the issue message. The formatted value is passed as the only argument.
+	// If empty, `%s is an invalid value as <attribute>` is used. A did-you-mean suggestion is appended if any.
+	Message string
+	// Severity is the rule severity. If empty, tflint.ERROR is used.
+	Severity string
+	// Link is the rule reference link.
+	Link string
+	// DisabledByDefault disables the rule unless it is enabled by the config.
+	DisabledByDefault bool
+}
+
+// EnumRule is a rule built from EnumRuleSpec
+type EnumRule struct {
+	spec *EnumRuleSpec
+}
+
+// NewEnumRule returns a new rule built from the passed spec
+func NewEnumRule(spec *EnumRuleSpec) *EnumRule {
+	return &EnumRule{spec: spec}
+}
+
+// Name returns the rule name
+func (r *EnumRule) Name() string {
+	return r.spec.Name
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *EnumRule) Enabled() bool {
+	return !r.spec.DisabledByDefault
+}
+
+// Severity returns the rule severity
+func (r *EnumRule) Severity() string {
+	if r.spec.Severity == "" {
+		return tflint.ERROR
+	}
+	return r.spec.Severity
+}
+
+// Link returns the rule reference link
+func (r *EnumRule) Link() string {
+	return r.spec.Link
+}
+
+// Check checks whether the attribute value is one of the allowed values
+func (r *EnumRule) Check(runner tflint.Runner) error {
+	return runner.WalkResourceAttributes(r.spec.ResourceType, r.spec.AttributeName, func(attribute *hcl.Attribute) error {
+		var val string
+		err := runner.EvaluateExpr(attribute.Expr, &val)
+
+		return runner.EnsureNoError(err, func() error {
+			for _, allowed := range r.spec.Values {
+				if val == allowed {
+					return nil
+				}
+			}
+
+			return runner.EmitIssue(
+				r,
+				r.message(runner, attribute.Expr, val),
+				attribute.Expr.Range(),
+				tflint.Metadata{Expr: attribute.Expr},
+			)
+		})
+	})
+}
+
+func (r *EnumRule) message(runner tflint.Runner, expr hcl.Expression, val string) string {
+	format := r.spec.Message
+	if format == "" {
+		format = "%s is an invalid value as " + r.spec.AttributeName
+	}
+
+	formatted := tflint.FormatValue(runner, expr, val)
+	if formatted == tflint.RedactedValue {
+		// Suggestions can leak sensitive values
+		return fmt.Sprintf(format, formatted)
+	}
+	return fmt.Sprintf(format, formatted) + tflint.DidYouMean(val, r.spec.Values)
+}
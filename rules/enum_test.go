@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_EnumRule(t *testing.T) {
+	rule := NewEnumRule(&EnumRuleSpec{
+		Name:          "aws_instance_invalid_type",
+		ResourceType:  "aws_instance",
+		AttributeName: "instance_type",
+		Values:        []string{"t2.micro", "t2.small"},
+	})
+
+	cases := []struct {
+		Name       string
+		Content    string
+		Sensitives []string
+		Expected   helper.Issues
+	}{
+		{
+			Name: "valid",
+			Content: `
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "invalid",
+			Content: `
+resource "aws_instance" "web" {
+  instance_type = "t2.micor"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: `"t2.micor" is an invalid value as instance_type Did you mean "t2.micro"?`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 19},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name: "sensitive",
+			Content: `
+variable "type" {
+  default = "t2.micor"
+}
+
+resource "aws_instance" "web" {
+  instance_type = var.type
+}`,
+			Sensitives: []string{"type"},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: `*** is an invalid value as instance_type`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 19},
+						End:      hcl.Pos{Line: 7, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			Name: "unknown",
+			Content: `
+variable "type" {}
+
+resource "aws_instance" "web" {
+  instance_type = var.type
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+		runner.SensitiveVariables = tc.Sensitives
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
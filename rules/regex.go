@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// RegexRuleSpec is a declarative specification of a rule that checks whether an attribute value matches a pattern.
+type RegexRuleSpec struct {
+	// Name is the rule name (e.g. aws_s3_bucket_invalid_name).
+	Name string
+	// ResourceType and AttributeName are the target attribute (e.g. aws_s3_bucket and bucket).
+	ResourceType  string
+	AttributeName string
+	// Pattern is the regular expression that the value must match.
+	Pattern string
+	// MustNotMatch inverts the check, i.e. the value must not match the pattern.
+	MustNotMatch bool
+	// Message is a template of the issue message.
+	// `{{value}}`, `{{pattern}}` and `{{attribute}}` are replaced with the formatted value, the pattern and the attribute name.
+	// If empty, `{{value}} does not match {{pattern}}` (or `{{value}} must not match {{pattern}}`) is used.
+	Message string
+	// Severity is the rule severity. If empty, tflint.ERROR is used.
+	Severity string
+	// Link is the rule reference link.
+	Link string
+	// DisabledByDefault disables the rule unless it is enabled by the config.
+	DisabledByDefault bool
+}
+
+// RegexRule is a rule built from RegexRuleSpec
+type RegexRule struct {
+	spec    *RegexRuleSpec
+	pattern *regexp.Regexp
+}
+
+// NewRegexRule returns a new rule built from the passed spec
+// It panics if the pattern cannot be compiled, like regexp.MustCompile.
+func NewRegexRule(spec *RegexRuleSpec) *RegexRule {
+	return &RegexRule{spec: spec, pattern: regexp.MustCompile(spec.Pattern)}
+}
+
+// Name returns the rule name
+func (r *RegexRule) Name() string {
+	return r.spec.Name
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *RegexRule) Enabled() bool {
+	return !r.spec.DisabledByDefault
+}
+
+// Severity returns the rule severity
+func (r *RegexRule) Severity() string {
+	if r.spec.Severity == "" {
+		return tflint.ERROR
+	}
+	return r.spec.Severity
+}
+
+// Link returns the rule reference link
+func (r *RegexRule) Link() string {
+	return r.spec.Link
+}
+
+// Check checks whether the attribute value matches (or does not match) the pattern
+func (r *RegexRule) Check(runner tflint.Runner) error {
+	return runner.WalkResourceAttributes(r.spec.ResourceType, r.spec.AttributeName, func(attribute *hcl.Attribute) error {
+		var val string
+		err := runner.EvaluateExpr(attribute.Expr, &val)
+
+		return runner.EnsureNoError(err, func() error {
+			if r.pattern.MatchString(val) != r.spec.MustNotMatch {
+				return nil
+			}
+
+			return runner.EmitIssue(
+				r,
+				r.message(tflint.FormatValue(runner, attribute.Expr, val)),
+				attribute.Expr.Range(),
+				tflint.Metadata{Expr: attribute.Expr},
+			)
+		})
+	})
+}
+
+func (r *RegexRule) message(value string) string {
+	template := r.spec.Message
+	if template == "" {
+		template = "{{value}} does not match {{pattern}}"
+		if r.spec.MustNotMatch {
+			template = "{{value}} must not match {{pattern}}"
+		}
+	}
+
+	return strings.NewReplacer(
+		"{{value}}", value,
+		"{{pattern}}", r.spec.Pattern,
+		"{{attribute}}", r.spec.AttributeName,
+	).Replace(template)
+}
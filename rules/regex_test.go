@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_RegexRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Spec     *RegexRuleSpec
+		Content  string
+		Expected []string
+	}{
+		{
+			Name: "match",
+			Spec: &RegexRuleSpec{Pattern: `^[a-z0-9-]+$`},
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs-bucket"
+}`,
+			Expected: []string{},
+		},
+		{
+			Name: "not match",
+			Spec: &RegexRuleSpec{Pattern: `^[a-z0-9-]+$`},
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "Logs_Bucket"
+}`,
+			Expected: []string{`"Logs_Bucket" does not match ^[a-z0-9-]+$`},
+		},
+		{
+			Name: "must not match",
+			Spec: &RegexRuleSpec{Pattern: `^test-`, MustNotMatch: true},
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "test-logs"
+}`,
+			Expected: []string{`"test-logs" must not match ^test-`},
+		},
+		{
+			Name: "custom message",
+			Spec: &RegexRuleSpec{Pattern: `^[a-z0-9-]+$`, Message: "{{attribute}} {{value}} is invalid"},
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "Logs"
+}`,
+			Expected: []string{`bucket "Logs" is invalid`},
+		},
+	}
+
+	for _, tc := range cases {
+		tc.Spec.Name = "aws_s3_bucket_invalid_name"
+		tc.Spec.ResourceType = "aws_s3_bucket"
+		tc.Spec.AttributeName = "bucket"
+		rule := NewRegexRule(tc.Spec)
+
+		runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+
+		expected := helper.Issues{}
+		for _, message := range tc.Expected {
+			expected = append(expected, &helper.Issue{Rule: rule, Message: message})
+		}
+		helper.AssertIssuesWithoutRange(t, expected, runner.Issues)
+	}
+}
@@ -0,0 +1,200 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// RequiredKeysRuleSpec is a declarative specification of a rule that checks whether a map attribute
+// contains the required keys with non-empty values (e.g. required tags).
+type RequiredKeysRuleSpec struct {
+	// Name is the rule name (e.g. aws_resource_missing_tags).
+	Name string
+	// ResourceTypes is the list of target resource types.
+	ResourceTypes []string
+	// AttributeName is the target map attribute (e.g. tags).
+	AttributeName string
+	// Keys is the list of required keys. It can be overridden by the rule config.
+	Keys []string
+	// ConfigAttributeName is the name of the attribute in the rule config that overrides Keys.
+	// If empty, `keys` is used.
+	//
+	//	rule "aws_resource_missing_tags" {
+	//	  enabled = true
+	//	  keys    = ["Environment", "Owner"]
+	//	}
+	ConfigAttributeName string
+	// Severity is the rule severity. If empty, tflint.NOTICE is used.
+	Severity string
+	// Link is the rule reference link.
+	Link string
+	// DisabledByDefault disables the rule unless it is enabled by the config.
+	DisabledByDefault bool
+}
+
+// RequiredKeysRule is a rule built from RequiredKeysRuleSpec
+type RequiredKeysRule struct {
+	spec *RequiredKeysRuleSpec
+	keys []string
+}
+
+// NewRequiredKeysRule returns a new rule built from the passed spec
+func NewRequiredKeysRule(spec *RequiredKeysRuleSpec) *RequiredKeysRule {
+	return &RequiredKeysRule{spec: spec, keys: spec.Keys}
+}
+
+// Name returns the rule name
+func (r *RequiredKeysRule) Name() string {
+	return r.spec.Name
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *RequiredKeysRule) Enabled() bool {
+	return !r.spec.DisabledByDefault
+}
+
+// Severity returns the rule severity
+func (r *RequiredKeysRule) Severity() string {
+	if r.spec.Severity == "" {
+		return tflint.NOTICE
+	}
+	return r.spec.Severity
+}
+
+// Link returns the rule reference link
+func (r *RequiredKeysRule) Link() string {
+	return r.spec.Link
+}
+
+// ApplyConfig overrides the required keys by the rule config
+func (r *RequiredKeysRule) ApplyConfig(config *tflint.RuleConfig) error {
+	name := r.spec.ConfigAttributeName
+	if name == "" {
+		name = "keys"
+	}
+
+	val, exists := config.Options[name]
+	if !exists {
+		return nil
+	}
+
+	var keys []string
+	val, err := convert.Convert(val, cty.List(cty.String))
+	if err == nil {
+		err = gocty.FromCtyValue(val, &keys)
+	}
+	if err != nil {
+		return fmt.Errorf("`%s` must be a list of strings: %s", name, err)
+	}
+	r.keys = keys
+	return nil
+}
+
+// Check checks whether the map attribute contains the required keys with non-empty values
+func (r *RequiredKeysRule) Check(runner tflint.Runner) error {
+	for _, resourceType := range r.spec.ResourceTypes {
+		resources, err := runner.GetResourceAttributeNames(resourceType)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			if resource.Has(r.spec.AttributeName) {
+				continue
+			}
+			err := runner.EmitIssue(r, r.missingMessage(r.keys), resource.DeclRange, tflint.Metadata{})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = runner.WalkResourceAttributes(resourceType, r.spec.AttributeName, func(attribute *hcl.Attribute) error {
+			// The value is evaluated as is, so that known keys are checked even if other values are unknown.
+			var val cty.Value
+			err := runner.EvaluateExpr(attribute.Expr, &val)
+
+			return runner.EnsureNoError(err, func() error {
+				if !val.IsKnown() || val.IsNull() || !val.CanIterateElements() {
+					return nil
+				}
+				values := map[string]cty.Value{}
+				for it := val.ElementIterator(); it.Next(); {
+					key, v := it.Element()
+					if key.Type() == cty.String && key.IsKnown() {
+						values[key.AsString()] = v
+					}
+				}
+
+				missing := []string{}
+				empty := []string{}
+				for _, key := range r.keys {
+					v, exists := values[key]
+					switch {
+					case !exists:
+						missing = append(missing, key)
+					case emptyValue(v):
+						empty = append(empty, key)
+					}
+				}
+
+				if len(missing) > 0 {
+					err := runner.EmitIssue(r, r.missingMessage(missing), attribute.Expr.Range(), tflint.Metadata{Expr: attribute.Expr})
+					if err != nil {
+						return err
+					}
+				}
+				if len(empty) > 0 {
+					return runner.EmitIssue(
+						r,
+						fmt.Sprintf("The following %s must not be empty: %s", r.spec.AttributeName, quoteJoin(empty)),
+						attribute.Expr.Range(),
+						tflint.Metadata{Expr: attribute.Expr},
+					)
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emptyValue returns true if the value is null or a blank string. Unknown values are not considered empty.
+func emptyValue(val cty.Value) bool {
+	if val.IsNull() {
+		return true
+	}
+	if !val.IsKnown() {
+		return false
+	}
+	str, err := convert.Convert(val, cty.String)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(str.AsString()) == ""
+}
+
+func (r *RequiredKeysRule) missingMessage(keys []string) string {
+	return fmt.Sprintf("The resource is missing the following %s: %s", r.spec.AttributeName, quoteJoin(keys))
+}
+
+func quoteJoin(list []string) string {
+	sorted := make([]string, len(list))
+	copy(sorted, list)
+	sort.Strings(sorted)
+
+	quoted := make([]string, len(sorted))
+	for i, s := range sorted {
+		quoted[i] = fmt.Sprintf(`"%s"`, s)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_RequiredKeysRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "all keys",
+			Content: `
+resource "aws_instance" "web" {
+  tags = { Environment = "prod", Owner = "team" }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing and empty keys",
+			Content: `
+resource "aws_instance" "web" {
+  tags = { Environment = "" }
+}`,
+			Expected: helper.Issues{
+				{
+					Message: `The resource is missing the following tags: "Owner"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 30},
+					},
+				},
+				{
+					Message: `The following tags must not be empty: "Environment"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "missing attribute",
+			Content: `
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}`,
+			Expected: helper.Issues{
+				{
+					Message: `The resource is missing the following tags: "Environment", "Owner"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "unknown",
+			Content: `
+variable "tags" {}
+
+resource "aws_instance" "web" {
+  tags = var.tags
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "partially unknown",
+			Content: `
+variable "environment" {}
+
+resource "aws_instance" "web" {
+  tags = { Environment = var.environment }
+}`,
+			Expected: helper.Issues{
+				{
+					Message: `The resource is missing the following tags: "Owner"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 43},
+					},
+				},
+			},
+		},
+		{
+			Name: "config",
+			Content: `
+resource "aws_instance" "web" {
+  tags = { Environment = "prod", Owner = "team" }
+}`,
+			Config: `keys = ["CostCenter"]`,
+			Expected: helper.Issues{
+				{
+					Message: `The resource is missing the following tags: "CostCenter"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 50},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		rule := NewRequiredKeysRule(&RequiredKeysRuleSpec{
+			Name:          "aws_resource_missing_tags",
+			ResourceTypes: []string{"aws_instance", "aws_s3_bucket"},
+			AttributeName: "tags",
+			Keys:          []string{"Environment", "Owner"},
+		})
+
+		if tc.Config != "" {
+			file, diags := hclsyntax.ParseConfig([]byte(tc.Config), ".tflint.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			options, diags := tflint.DecodeRuleOptions(file.Body, nil)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			if err := rule.ApplyConfig(&tflint.RuleConfig{Name: rule.Name(), Enabled: true, Options: options}); err != nil {
+				t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+			}
+		}
+
+		runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+
+		for _, issue := range tc.Expected {
+			issue.Rule = rule
+		}
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
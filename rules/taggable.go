@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// TaggableResources is a provider-agnostic mapping of resource types to the names of the map attributes holding their tags
+// (e.g. `tags` for AWS and Azure, `labels` for Google Cloud), shared by tagging-policy rules across providers.
+//
+// Keys are resource types or glob patterns (e.g. `aws_*`). An exact type takes precedence over patterns,
+// and a longer pattern takes precedence over shorter ones. An empty attribute name marks the type as not taggable.
+//
+//	{
+//	  "aws_*": "tags",
+//	  "aws_autoscaling_group": "",
+//	  "google_*": "labels"
+//	}
+type TaggableResources map[string]string
+
+// DefaultTaggableResources is the well-known mapping of major providers.
+// It is coarse because not all resources of a provider are taggable. Refine it with Merge as needed.
+var DefaultTaggableResources = TaggableResources{
+	"aws_*":     "tags",
+	"azurerm_*": "tags",
+	"google_*":  "labels",
+}
+
+// ParseTaggableResources parses the mapping in JSON.
+func ParseTaggableResources(src []byte) (TaggableResources, error) {
+	var ret TaggableResources
+	if err := json.Unmarshal(src, &ret); err != nil {
+		return nil, fmt.Errorf("Failed to parse taggable resources: %s", err)
+	}
+	return ret, nil
+}
+
+// LoadTaggableResources loads the mapping from the passed JSON file.
+func LoadTaggableResources(path string) (TaggableResources, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read taggable resources: %s", err)
+	}
+	return ParseTaggableResources(src)
+}
+
+// Merge returns a new mapping that overrides the receiver with the passed mapping.
+func (t TaggableResources) Merge(other TaggableResources) TaggableResources {
+	ret := TaggableResources{}
+	for pattern, name := range t {
+		ret[pattern] = name
+	}
+	for pattern, name := range other {
+		ret[pattern] = name
+	}
+	return ret
+}
+
+// TagAttribute returns the name of the tag attribute of the passed resource type.
+// It returns false if the resource type is not taggable.
+func (t TaggableResources) TagAttribute(resourceType string) (string, bool) {
+	if name, exists := t[resourceType]; exists {
+		return name, name != ""
+	}
+
+	matched := ""
+	for pattern := range t {
+		if !tflint.MatchResourceType(pattern, resourceType) {
+			continue
+		}
+		if len(pattern) > len(matched) || (len(pattern) == len(matched) && pattern < matched) {
+			matched = pattern
+		}
+	}
+	if matched == "" {
+		return "", false
+	}
+	return t[matched], t[matched] != ""
+}
+
+// TaggedResource is a resource of a taggable type with its tag attribute.
+type TaggedResource struct {
+	Type string
+	Name string
+	// Ranges is the set of ranges of the resource block.
+	Ranges tflint.BlockRanges
+	// AttributeName is the name of the tag attribute of the resource type (e.g. `tags`).
+	AttributeName string
+	// Attribute is the tag attribute. It is nil if not declared.
+	Attribute *hcl.Attribute
+}
+
+// WalkTags passes each resource of taggable types to the walker function with its tag attribute.
+// Resources are passed even if the tag attribute is not declared, so rules can check for required tags.
+//
+// Example:
+//
+//	err := rules.DefaultTaggableResources.WalkTags(runner, func(resource *rules.TaggedResource) error {
+//		if resource.Attribute == nil {
+//			return runner.EmitIssue(rule, fmt.Sprintf("%s is required", resource.AttributeName), resource.Ranges.DefRange, tflint.Metadata{})
+//		}
+//		var tags map[string]string
+//		err := runner.EvaluateExpr(resource.Attribute.Expr, &tags)
+//		...
+//	})
+func (t TaggableResources) WalkTags(runner tflint.Runner, walker func(*TaggedResource) error) error {
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	resources := append([]*hcl.Block{}, content.Blocks...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].DefRange.Filename != resources[j].DefRange.Filename {
+			return resources[i].DefRange.Filename < resources[j].DefRange.Filename
+		}
+		return resources[i].DefRange.Start.Byte < resources[j].DefRange.Start.Byte
+	})
+
+	for _, resource := range resources {
+		name, ok := t.TagAttribute(resource.Labels[0])
+		if !ok {
+			continue
+		}
+
+		body, _, diags := resource.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{{Name: name}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		err := walker(&TaggedResource{
+			Type:          resource.Labels[0],
+			Name:          resource.Labels[1],
+			Ranges:        tflint.NewBlockRanges(resource),
+			AttributeName: name,
+			Attribute:     body.Attributes[name],
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TaggableResources_TagAttribute(t *testing.T) {
+	mapping, err := ParseTaggableResources([]byte(`{
+  "aws_autoscaling_group": "",
+  "aws_s3_*": "bucket_tags",
+  "custom_*": "metadata"
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping = DefaultTaggableResources.Merge(mapping)
+
+	cases := []struct {
+		ResourceType string
+		Expected     string
+		Taggable     bool
+	}{
+		{ResourceType: "aws_instance", Expected: "tags", Taggable: true},
+		{ResourceType: "aws_s3_bucket", Expected: "bucket_tags", Taggable: true},
+		{ResourceType: "aws_autoscaling_group", Taggable: false},
+		{ResourceType: "google_compute_instance", Expected: "labels", Taggable: true},
+		{ResourceType: "custom_thing", Expected: "metadata", Taggable: true},
+		{ResourceType: "null_resource", Taggable: false},
+	}
+
+	for _, tc := range cases {
+		name, taggable := mapping.TagAttribute(tc.ResourceType)
+		if name != tc.Expected || taggable != tc.Taggable {
+			t.Fatalf("Failed `%s` test: expected (%s, %t), but got (%s, %t)", tc.ResourceType, tc.Expected, tc.Taggable, name, taggable)
+		}
+	}
+
+	if _, err := ParseTaggableResources([]byte(`["tags"]`)); err == nil {
+		t.Fatal("Expected an error, but no error occurred")
+	}
+}
+
+func Test_TaggableResources_WalkTags(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  tags = { Name = "web" }
+}
+
+resource "google_compute_instance" "web" {
+  name = "web"
+}
+
+resource "null_resource" "noop" {}`})
+
+	got := []string{}
+	err := DefaultTaggableResources.WalkTags(runner, func(resource *TaggedResource) error {
+		got = append(got, fmt.Sprintf("%s.%s: %s (%t)", resource.Type, resource.Name, resource.AttributeName, resource.Attribute != nil))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []string{
+		"aws_instance.web: tags (true)",
+		"google_compute_instance.web: labels (false)",
+	}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+}
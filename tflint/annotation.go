@@ -0,0 +1,77 @@
+package tflint
+
+import (
+	"regexp"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ignoreLinePattern matches `# tflint-ignore-line: rule_name, other_rule` comments
+var ignoreLinePattern = regexp.MustCompile(`^(?:#|//)\s*tflint-ignore-line:\s*([^\n]+)`)
+
+// Annotation is a `tflint-ignore-line` comment, which suppresses the rules on the line where it is placed.
+//
+// Example:
+//
+//	instance_type = "t1.2xlarge" # tflint-ignore-line: aws_instance_invalid_type
+type Annotation struct {
+	// Rules is a list of rule names to be ignored. "all" ignores all rules.
+	Rules []string
+	Range hcl.Range
+}
+
+// Ignores returns true if the annotation ignores the rule on the line where the passed range starts.
+func (a Annotation) Ignores(rule string, rng hcl.Range) bool {
+	if a.Range.Filename != rng.Filename || a.Range.Start.Line != rng.Start.Line {
+		return false
+	}
+	for _, name := range a.Rules {
+		if name == rule || name == "all" {
+			return true
+		}
+	}
+	return false
+}
+
+// Annotations is a list of annotations.
+type Annotations []Annotation
+
+// Ignores returns true if any annotation ignores the rule at the passed range.
+func (as Annotations) Ignores(rule string, rng hcl.Range) bool {
+	for _, annotation := range as {
+		if annotation.Ignores(rule, rng) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAnnotations finds annotations in the passed source in native syntax.
+func NewAnnotations(filename string, src []byte) (Annotations, hcl.Diagnostics) {
+	tokens, diags := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := Annotations{}
+	for _, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+		match := ignoreLinePattern.FindSubmatch(token.Bytes)
+		if match == nil {
+			continue
+		}
+
+		rules := []string{}
+		for _, name := range strings.Split(string(match[1]), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				rules = append(rules, name)
+			}
+		}
+		ret = append(ret, Annotation{Rules: rules, Range: token.Range})
+	}
+	return ret, nil
+}
@@ -0,0 +1,77 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_NewAnnotations(t *testing.T) {
+	src := []byte(`
+resource "aws_instance" "web" {
+  instance_type = "t1.2xlarge" # tflint-ignore-line: aws_instance_invalid_type, aws_instance_previous_type
+  ami           = "ami-1234"   // tflint-ignore-line: all
+  # tflint-ignore: aws_instance_invalid_ami
+  tags = {}
+}`)
+
+	annotations, diags := NewAnnotations("main.tf", src)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	expected := [][]string{{"aws_instance_invalid_type", "aws_instance_previous_type"}, {"all"}}
+	got := [][]string{}
+	for _, annotation := range annotations {
+		got = append(got, annotation.Rules)
+	}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+
+	cases := []struct {
+		Name     string
+		Rule     string
+		Range    hcl.Range
+		Expected bool
+	}{
+		{
+			Name:     "listed rule",
+			Rule:     "aws_instance_previous_type",
+			Range:    hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}},
+			Expected: true,
+		},
+		{
+			Name:     "unlisted rule",
+			Rule:     "aws_instance_invalid_ami",
+			Range:    hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}},
+			Expected: false,
+		},
+		{
+			Name:     "all",
+			Rule:     "aws_instance_invalid_ami",
+			Range:    hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 4}},
+			Expected: true,
+		},
+		{
+			Name:     "other line",
+			Rule:     "aws_instance_invalid_type",
+			Range:    hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 6}},
+			Expected: false,
+		},
+		{
+			Name:     "other file",
+			Rule:     "aws_instance_invalid_type",
+			Range:    hcl.Range{Filename: "other.tf", Start: hcl.Pos{Line: 3}},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		got := annotations.Ignores(tc.Rule, tc.Range)
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected %t, but got %t", tc.Name, tc.Expected, got)
+		}
+	}
+}
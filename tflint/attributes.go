@@ -0,0 +1,77 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// ResourceAttributes is a set of attributes fetched together from the same resource.
+// Cross-attribute rules can use it instead of correlating separate walks by range.
+type ResourceAttributes struct {
+	Type   string
+	Name   string
+	Ranges BlockRanges
+	// Attributes is a set of the requested attributes keyed by name. Attributes not declared are absent.
+	Attributes map[string]*hcl.Attribute
+}
+
+// Has returns true if the resource has the passed attribute.
+func (r *ResourceAttributes) Has(name string) bool {
+	_, exists := r.Attributes[name]
+	return exists
+}
+
+// Evaluate evaluates the passed attribute in the same way as Runner.EvaluateExpr.
+// It returns false without an error if the attribute is not declared.
+func (r *ResourceAttributes) Evaluate(runner Runner, name string, ret interface{}) (bool, error) {
+	attribute, exists := r.Attributes[name]
+	if !exists {
+		return false, nil
+	}
+	return true, runner.EvaluateExpr(attribute.Expr, ret)
+}
+
+// WalkResourceAttributesTogether fetches the passed attributes of each resource of the type in a single request,
+// and passes them to the walker function together, so that they can be evaluated jointly.
+//
+// Example:
+//
+//	err := tflint.WalkResourceAttributesTogether(runner, "aws_db_instance", []string{"storage_encrypted", "kms_key_id"}, func(resource *tflint.ResourceAttributes) error {
+//		if resource.Has("kms_key_id") && !resource.Has("storage_encrypted") {
+//			return runner.EmitIssue(rule, "storage_encrypted must be set with kms_key_id", resource.Ranges.DefRange, tflint.Metadata{})
+//		}
+//		return nil
+//	})
+func WalkResourceAttributesTogether(runner Runner, resourceType string, names []string, walker func(*ResourceAttributes) error) error {
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	schema := &hcl.BodySchema{Attributes: make([]hcl.AttributeSchema, len(names))}
+	for i, name := range names {
+		schema.Attributes[i] = hcl.AttributeSchema{Name: name}
+	}
+
+	for _, resource := range content.Blocks {
+		if resource.Labels[0] != resourceType {
+			continue
+		}
+
+		body, _, diags := resource.Body.PartialContent(schema)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		err := walker(&ResourceAttributes{
+			Type:       resource.Labels[0],
+			Name:       resource.Labels[1],
+			Ranges:     NewBlockRanges(resource),
+			Attributes: body.Attributes,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// Backend is a `backend` block declared in a terraform block.
+type Backend struct {
+	// Type is the backend type (e.g. "s3", "gcs", "remote").
+	Type      string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the backend block.
+	Ranges BlockRanges
+	// Attributes is the set of attributes keyed by name (e.g. bucket, key, encrypt).
+	// Nested blocks (e.g. `workspaces`) are not included in native syntax.
+	Attributes map[string]*hcl.Attribute
+}
+
+// NewBackend extracts the backend configuration from the passed backend block.
+func NewBackend(backend *hcl.Block) (*Backend, hcl.Diagnostics) {
+	attributes, diags := bodyAttributes(backend.Body)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Backend{
+		Type:       backend.Labels[0],
+		DeclRange:  backend.DefRange,
+		Ranges:     NewBlockRanges(backend),
+		Attributes: attributes,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package tflint
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// BlockRanges is the set of ranges of a block.
+// It allows rules to point precisely at the block header, a label, or the whole body, instead of borrowing an attribute's range.
+type BlockRanges struct {
+	// DefRange is the range of the block header (e.g. `resource "aws_instance" "web"`).
+	DefRange hcl.Range
+	// TypeRange is the range of the block type (e.g. `resource`).
+	TypeRange hcl.Range
+	// LabelRanges is a list of ranges of each label (e.g. `"aws_instance"` and `"web"`).
+	LabelRanges []hcl.Range
+	// BodyRange is the range of the body including the braces.
+	// For bodies in JSON syntax, it is the same as DefRange because their ranges are not available.
+	BodyRange hcl.Range
+}
+
+// NewBlockRanges returns the ranges of the passed block.
+func NewBlockRanges(block *hcl.Block) BlockRanges {
+	ranges := BlockRanges{
+		DefRange:    block.DefRange,
+		TypeRange:   block.TypeRange,
+		LabelRanges: block.LabelRanges,
+		BodyRange:   block.DefRange,
+	}
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		ranges.BodyRange = body.SrcRange
+	}
+	return ranges
+}
+
+// Range returns the range of the whole block from the type to the end of the body.
+func (r BlockRanges) Range() hcl.Range {
+	return hcl.RangeBetween(r.TypeRange, r.BodyRange)
+}
+
+// parseBlockBody parses the passed source of the block in native syntax and sets the body.
+// Bodies cannot always be sent via RPC as they are (e.g. expressions with operators), so hosts send the source instead.
+func parseBlockBody(block *hcl.Block, src []byte) hcl.Diagnostics {
+	file, diags := hclsyntax.ParseConfig(src, block.TypeRange.Filename, block.TypeRange.Start)
+	if diags.HasErrors() {
+		return diags
+	}
+	labels := make([]string, len(block.Labels))
+	for i := range labels {
+		labels[i] = fmt.Sprintf("label%d", i)
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: block.Type, LabelNames: labels}},
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+	if len(content.Blocks) != 1 {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid block source",
+			Detail:   fmt.Sprintf("The source of the `%s` block must contain exactly one block", block.Type),
+			Subject:  &block.DefRange,
+		}}
+	}
+	block.Body = content.Blocks[0].Body
+	return nil
+}
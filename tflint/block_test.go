@@ -0,0 +1,38 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_NewBlockRanges(t *testing.T) {
+	file, diags := hclsyntax.ParseConfig([]byte(`
+resource "aws_instance" "web" {
+  ami = "ami-1234"
+}`), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	block := file.Body.(*hclsyntax.Body).Blocks[0].AsHCLBlock()
+
+	ranges := NewBlockRanges(block)
+
+	got := []string{ranges.DefRange.String(), ranges.TypeRange.String(), ranges.BodyRange.String(), ranges.Range().String()}
+	for _, label := range ranges.LabelRanges {
+		got = append(got, label.String())
+	}
+	expected := []string{
+		"main.tf:2,1-30",
+		"main.tf:2,1-9",
+		"main.tf:2,31-4,2",
+		"main.tf:2,1-4,2",
+		"main.tf:2,10-24",
+		"main.tf:2,25-30",
+	}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Unexpected ranges: %s", cmp.Diff(expected, got))
+	}
+}
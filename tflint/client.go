@@ -1,13 +1,19 @@
 package tflint
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"math/rand"
 	"net"
 	"net/rpc"
+	"reflect"
+	"sync"
+	"time"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 	"github.com/zclconf/go-cty/cty/gocty"
 )
 
@@ -15,40 +21,1138 @@ import (
 // Actually, it is an RPC client, but its details are hidden on the plugin side because it satisfies the Runner interface
 type Client struct {
 	rpcClient *rpc.Client
+	logger    Logger
+	// ctx is the context of the rule being checked. It is canceled when the rule finishes or exceeds the call budget.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// mu guards the counters and caches below, so that rules can query the host concurrently (see WalkConcurrently).
+	mu sync.Mutex
+	// timeout is the maximum duration of each RPC call. 0 means no timeout.
+	timeout  time.Duration
+	linker   func(Rule) string
+	metadata *RunMetadata
+	// modulePath is the cached result of ModulePath. It is only valid if modulePathFetched is true.
+	modulePath        ModulePath
+	modulePathFetched bool
+	// empty is the cached result of IsEmpty. It is only valid if emptyFetched is true.
+	empty        bool
+	emptyFetched bool
+	offline      bool
+	// sensitives is a set of ranges of expressions that the host reported as sensitive.
+	sensitives map[hcl.Range]bool
+	// emitted is a set of issues already emitted by rules that deduplicate issues.
+	emitted map[issueKey]bool
+	// rule is the name of the rule being checked.
+	rule string
+	// budget is the maximum number of RPC calls per rule. 0 means no limit.
+	budget int
+	// calls is the number of RPC calls made by the rule being checked.
+	calls int
+	// annotationsMu guards the annotations below. It is held while fetching them, so that concurrent walks
+	// wait for the first fetch instead of seeing no annotations. It is separate from mu because RPC calls take mu.
+	annotationsMu sync.Mutex
+	// annotations is the cached result of the Annotations query. It is only valid if annotationsFetched is true.
+	annotations        Annotations
+	annotationsFetched bool
+	// shared is a set of responses shared among rules in the run, keyed by the method and the request.
+	// It is nil if sharing is disabled.
+	shared map[string]interface{}
+	// sharedCacheSize is the maximum number of shared responses. 0 means no limit.
+	sharedCacheSize int
+	// root is the module root on the host (RunMetadata.ModuleRoot). Received filenames are normalized relative to it.
+	// It is fetched only once by moduleRoot.
+	root     string
+	rootOnce sync.Once
 }
 
+type issueKey struct {
+	rule     string
+	message  string
+	location hcl.Range
+}
+
+// ClientCodec is an alias of rpc.ClientCodec to select codecs in Options without importing net/rpc.
+type ClientCodec = rpc.ClientCodec
+
 // NewClient returns a new Client
+// Types sent via RPC are registered in gob, so the Client works regardless of which packages are imported.
 func NewClient(conn net.Conn) *Client {
-	return &Client{rpcClient: rpc.NewClient(conn)}
+	return NewClientWithOptions(conn, Options{})
+}
+
+// NewClientWithOptions returns a new Client configured with the passed options
+func NewClientWithOptions(conn net.Conn, opts Options) *Client {
+	RegisterWireTypes()
+
+	// Only one client must read from the connection, otherwise replies are stolen by the other.
+	var rpcClient *rpc.Client
+	if opts.Codec != nil {
+		rpcClient = rpc.NewClientWithCodec(opts.Codec(conn))
+	} else {
+		rpcClient = rpc.NewClient(conn)
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = standardLogger{}
+	}
+
+	return &Client{
+		rpcClient:       rpcClient,
+		logger:          logger,
+		timeout:         opts.Timeout,
+		sharedCacheSize: opts.SharedCacheSize,
+		sensitives:      map[hcl.Range]bool{},
+		emitted:         map[issueKey]bool{},
+	}
+}
+
+// call calls the RPC method and wraps the error with the method name and the request summary.
+// Calls exceeding the budget fail without querying the host, so the rule is aborted as soon as it returns the error.
+// Emitting issues and records, reporting progress and fetching annotations are not counted, as these calls do not query the configuration.
+func (c *Client) call(method string, args interface{}, reply interface{}) error {
+	if method != "Plugin.EmitIssue" && method != "Plugin.EmitRecord" && method != "Plugin.Progress" && method != "Plugin.Annotations" {
+		c.mu.Lock()
+		c.calls++
+		exceeded := c.budget > 0 && c.calls > c.budget
+		c.mu.Unlock()
+		if exceeded {
+			// Concurrent walks of the rule stop as soon as the budget is exceeded.
+			if c.cancel != nil {
+				c.cancel()
+			}
+			return CallBudgetError{Rule: c.rule, Budget: c.budget, Method: method}
+		}
+	}
+
+	if err := c.rpcCall(method, args, reply); err != nil {
+		err := ProtocolError{Method: method, Request: summarizeRequest(args), Cause: err}
+		c.logger.Printf("[ERROR] %s", err)
+		return err
+	}
+	return nil
+}
+
+// rpcCall calls the RPC method within the timeout.
+// On timeout, the response is decoded into a separate value that is discarded, so the reply is never written after returning.
+func (c *Client) rpcCall(method string, args interface{}, reply interface{}) error {
+	if c.timeout == 0 {
+		return c.rpcClient.Call(method, args, reply)
+	}
+
+	ret := reflect.New(reflect.TypeOf(reply).Elem())
+	call := c.rpcClient.Go(method, args, ret.Interface(), make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		if call.Error != nil {
+			return call.Error
+		}
+		reflect.ValueOf(reply).Elem().Set(ret.Elem())
+		return nil
+	case <-time.After(c.timeout):
+		return TimeoutError{Timeout: c.timeout}
+	}
+}
+
+// callShared is the same as call, but if sharing is enabled, the host is queried only once per run for the same request,
+// and later calls, including calls from other rules, are served from the shared response without RPC calls.
+// The reply must be a pointer to a response struct. Filenames in the reply are decoded.
+// The reply is always a copy of the shared response, so modifying attributes and blocks in it does not affect other walks.
+func (c *Client) callShared(method string, args interface{}, reply interface{}) error {
+	if c.shared == nil {
+		if err := c.call(method, args, reply); err != nil {
+			return err
+		}
+		c.decodeFilenames(reply)
+		return nil
+	}
+
+	key := sharedKey(method, args)
+	c.mu.Lock()
+	shared, exists := c.shared[key]
+	c.mu.Unlock()
+	if exists {
+		c.logger.Printf("[DEBUG] Reuse the shared response of %s", key)
+		reflect.ValueOf(reply).Elem().Set(reflect.ValueOf(copyResponse(shared)).Elem())
+		return nil
+	}
+
+	if err := c.call(method, args, reply); err != nil {
+		return err
+	}
+	c.share(key, reply)
+	return nil
+}
+
+// share stores a copy of the response as shared unless the number of shared responses reaches the limit.
+// Filenames in the response are decoded before being stored, so shared responses are never modified after that.
+func (c *Client) share(key string, reply interface{}) {
+	c.decodeFilenames(reply)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sharedCacheSize > 0 && len(c.shared) >= c.sharedCacheSize {
+		c.logger.Printf("[DEBUG] Skip sharing the response of %s as the cache is full", key)
+		return
+	}
+	c.shared[key] = copyResponse(reply)
+}
+
+// sharedKey returns the key of shared responses for the passed method and request
+func sharedKey(method string, args interface{}) string {
+	return fmt.Sprintf("%s(%s)", method, summarizeRequest(args))
+}
+
+func summarizeRequest(args interface{}) string {
+	switch req := args.(type) {
+	case AttributesRequest:
+		if !req.Fields.Has(AllAttributeFields) {
+			return fmt.Sprintf("%s.*.%s (%s)", req.Resource, req.AttributeName, req.Fields)
+		}
+		return fmt.Sprintf("%s.*.%s", req.Resource, req.AttributeName)
+	case DataSourceAttributesRequest:
+		return fmt.Sprintf("data.%s.*.%s", req.DataSource, req.AttributeName)
+	case BlocksRequest:
+		return fmt.Sprintf("%s.*.%s", req.Resource, req.BlockType)
+	case CountRequest:
+		if req.BlockType == "" {
+			return req.Resource
+		}
+		return fmt.Sprintf("%s.*.%s", req.Resource, req.BlockType)
+	case AttributeOrderRequest:
+		return req.Resource
+	case ResourceAttributeNamesRequest:
+		return req.Resource
+	case ResourcesRequest:
+		return req.Resource
+	case MetaArgumentsRequest:
+		return req.Resource
+	case ProvisionersRequest:
+		return req.Resource
+	case ModuleContentRequest:
+		if req.Schema == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("%d attributes, %d blocks", len(req.Schema.Attributes), len(req.Schema.Blocks))
+	case FilesRequest:
+		return req.Pattern
+	case EnvRequest:
+		return req.Name
+	case ProviderConfigRequest:
+		return fmt.Sprintf("%s.%s", req.Provider, req.Name)
+	case EvalExprRequest:
+		return req.Expr.Range().String()
+	case SourceRequest:
+		return req.Range.String()
+	case VariableFlowsRequest:
+		return "module." + req.Module
+	case *EmitIssueRequest:
+		return fmt.Sprintf("%s at %s", req.Rule.Data.Name, req.Location)
+	default:
+		return ""
+	}
+}
+
+// AnnotationsResponse is the interface used to communicate via RPC.
+type AnnotationsResponse struct {
+	Annotations Annotations
+	Err         error
+}
+
+// moduleRoot returns the module root on the host. It is fetched only once, and is empty if the host does not provide it.
+// It is not counted against the call budget because rules do not call it directly.
+func (c *Client) moduleRoot() string {
+	c.rootOnce.Do(func() {
+		var response RunMetadataResponse
+		if err := c.rpcCall("Plugin.RunMetadata", new(interface{}), &response); err != nil || response.Metadata == nil {
+			return
+		}
+		c.root = response.Metadata.ModuleRoot
+	})
+	return c.root
+}
+
+// ignored returns true if the range is on a line annotated with `tflint-ignore-line` for the rule being checked.
+// Attribute walkers skip such attributes before invoking the walker, so every plugin gets line-level suppression consistently.
+// Annotations are fetched only once. If fetching fails (e.g. the host does not support them), nothing is ignored and it is retried next time.
+func (c *Client) ignored(rng hcl.Range) bool {
+	if c.rule == "" {
+		return false
+	}
+
+	annotations, ok := c.fetchAnnotations()
+	if !ok {
+		return false
+	}
+
+	if annotations.Ignores(c.rule, rng) {
+		c.logger.Printf("[DEBUG] Skip `%s` rule at %s by annotation", c.rule, rng)
+		return true
+	}
+	return false
+}
+
+// fetchAnnotations returns the annotations, querying the host process only for the first time.
+// A failed query is not cached, so it is retried on the next call.
+func (c *Client) fetchAnnotations() (Annotations, bool) {
+	c.annotationsMu.Lock()
+	defer c.annotationsMu.Unlock()
+
+	if c.annotationsFetched {
+		return c.annotations, true
+	}
+
+	var response AnnotationsResponse
+	if err := c.call("Plugin.Annotations", new(interface{}), &response); err != nil {
+		return nil, false
+	}
+	if response.Err != nil {
+		c.logger.Printf("[ERROR] Failed to get annotations: %s", response.Err)
+		return nil, false
+	}
+	c.normalizeFilenames(&response.Annotations)
+
+	c.annotations = response.Annotations
+	c.annotationsFetched = true
+	return c.annotations, true
+}
+
+// AttributesRequest is the interface used to communicate via RPC.
+type AttributesRequest struct {
+	Resource      string
+	AttributeName string
+	// CompactFilenames requests the host to encode filenames in ranges with a FilenameTable.
+	CompactFilenames bool
+	// Fields is the set of fields of attributes to be transferred. 0 selects all fields.
+	Fields AttributeFields
+}
+
+// AttributesResponse is the interface used to communicate via RPC.
+type AttributesResponse struct {
+	Attributes []*hcl.Attribute
+	// Filenames is the table of filenames referenced by ranges in Attributes. It is empty if filenames are not encoded.
+	Filenames FilenameTable
+	Err       error
+}
+
+// WalkResourceAttributes queries the host process, receives a list of attributes that match the conditions,
+// and passes each to the walker function.
+//
+// The resource type can be a glob pattern in path.Match syntax (e.g. `aws_*` to check `tags` of all AWS resources),
+// optionally followed by a resource name (e.g. `aws_instance.web`).
+// Matching is done by the host, so only matched attributes are transferred. See MatchResourceAddress.
+//
+// The attribute name can be a dotted path to address attributes in nested blocks (e.g. `root_block_device.volume_size`).
+// The leading segments are nested block types, and attributes in all blocks of the type are walked.
+//
+// The attributes passed to the walker are reused in subsequent walks to reduce allocations unless the ruleset enables ShareResults.
+// The walker must copy an attribute (e.g. `copied := *attribute`) if it retains it.
+func (c *Client) WalkResourceAttributes(resource, attributeName string, walker func(*hcl.Attribute) error) error {
+	return c.WalkResourceAttributesWithFields(resource, attributeName, AllAttributeFields, walker)
+}
+
+// WalkResourceAttributesWithFields is the same as WalkResourceAttributes, except that only the selected fields
+// of attributes are transferred and passed to the walker. Unselected fields are zero values.
+// It is useful for rules that purely check the presence or location of attributes, because expressions are the largest part of responses.
+//
+//	err := runner.WalkResourceAttributesWithFields("aws_instance", "tags", tflint.AttributeRange, func(attribute *hcl.Attribute) error {
+//		return runner.EmitIssue(rule, "tags are managed by default_tags", attribute.Range, tflint.Metadata{})
+//	})
+func (c *Client) WalkResourceAttributesWithFields(resource, attributeName string, fields AttributeFields, walker func(*hcl.Attribute) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s.*.%s` attribute", resource, attributeName)
+
+	// Shared responses outlive the walk, so they cannot be reused.
+	response := &AttributesResponse{}
+	if c.shared == nil {
+		response = getAttributesResponse()
+		defer putAttributesResponse(response)
+	}
+
+	req := AttributesRequest{Resource: resource, AttributeName: attributeName, CompactFilenames: true}
+	if !fields.Has(AllAttributeFields) {
+		req.Fields = fields
+	}
+	if err := c.callShared("Plugin.Attributes", req, response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, attribute := range response.Attributes {
+		if c.ignored(attribute.Range) {
+			continue
+		}
+		// Hosts that do not support projection send all fields.
+		if err := walker(ProjectAttribute(attribute, fields)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DataSourceAttributesRequest is the interface used to communicate via RPC.
+type DataSourceAttributesRequest struct {
+	DataSource    string
+	AttributeName string
+}
+
+// DataSourceAttributesResponse is the interface used to communicate via RPC.
+type DataSourceAttributesResponse struct {
+	Attributes []*hcl.Attribute
+	Err        error
+}
+
+// WalkDataSourceAttributes queries the host process, receives a list of attributes of data sources (e.g. `data "aws_ami"`)
+// that match the conditions, and passes each to the walker function. The data source type can be a glob pattern like WalkResourceAttributes.
+func (c *Client) WalkDataSourceAttributes(dataSource, attributeName string, walker func(*hcl.Attribute) error) error {
+	c.logger.Printf("[DEBUG] Walk `data.%s.*.%s` attribute", dataSource, attributeName)
+
+	var response DataSourceAttributesResponse
+	if err := c.callShared("Plugin.DataSourceAttributes", DataSourceAttributesRequest{DataSource: dataSource, AttributeName: attributeName}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, attribute := range response.Attributes {
+		if c.ignored(attribute.Range) {
+			continue
+		}
+		if err := walker(attribute); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DuplicateValuesResponse is the interface used to communicate via RPC.
+type DuplicateValuesResponse struct {
+	Groups []*DuplicateGroup
+	Err    error
+}
+
+// GetDuplicateValues queries the host process for attributes of resources that have the same evaluated value
+// (e.g. duplicate `cidr_block`). The host evaluates and groups the values, so it takes only one round trip.
+// Values that are not statically known are ignored. If Sensitive is true, do not include the value in issue messages.
+func (c *Client) GetDuplicateValues(resource, attributeName string) ([]*DuplicateGroup, error) {
+	c.logger.Printf("[DEBUG] Get duplicate `%s.*.%s` values", resource, attributeName)
+
+	var response DuplicateValuesResponse
+	if err := c.call("Plugin.DuplicateValues", AttributesRequest{Resource: resource, AttributeName: attributeName}, &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Groups, nil
+}
+
+// BlocksRequest is the interface used to communicate via RPC.
+type BlocksRequest struct {
+	Resource  string
+	BlockType string
+	// CompactFilenames requests the host to encode filenames in ranges with a FilenameTable.
+	CompactFilenames bool
+}
+
+// BlocksResponse is the interface used to communicate via RPC.
+type BlocksResponse struct {
+	Blocks []*hcl.Block
+	// Filenames is the table of filenames referenced by ranges in Blocks. It is empty if filenames are not encoded.
+	Filenames FilenameTable
+	Err       error
+}
+
+// WalkResourceBlocks queries the host process, receives a list of nested blocks (e.g. `ebs_block_device`, `lifecycle`)
+// of resources that match the conditions, and passes each to the walker function.
+// Block bodies are sent as is, so you can decode their attributes and nested blocks with the body's Content method.
+func (c *Client) WalkResourceBlocks(resource, blockType string, walker func(*hcl.Block) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s.*.%s` block", resource, blockType)
+
+	var response BlocksResponse
+	if err := c.callShared("Plugin.Blocks", BlocksRequest{Resource: resource, BlockType: blockType, CompactFilenames: true}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, block := range response.Blocks {
+		if err := walker(block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExpandedBlocksResponse is the interface used to communicate via RPC.
+type ExpandedBlocksResponse struct {
+	Blocks []*ExpandedBlock
+	Err    error
+}
+
+// WalkExpandedResourceBlocks is the same as WalkResourceBlocks, but the host expands `dynamic` blocks before passing blocks,
+// so rules also see blocks generated from `dynamic` blocks. Attributes are evaluated by the host with the iterator bound,
+// because expressions in `content` blocks refer to the iterator, which cannot be evaluated by EvaluateExpr.
+func (c *Client) WalkExpandedResourceBlocks(resource, blockType string, walker func(*ExpandedBlock) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s.*.%s` expanded blocks", resource, blockType)
+
+	var response ExpandedBlocksResponse
+	if err := c.call("Plugin.ExpandedBlocks", BlocksRequest{Resource: resource, BlockType: blockType}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, block := range response.Blocks {
+		if err := walker(block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EvaluatedAttribute is an attribute with the value evaluated by the host.
+type EvaluatedAttribute struct {
+	Attribute *hcl.Attribute
+	// Val is the evaluated value. It is nil if the value is not statically known.
+	Val *cty.Value
+	// Sensitive reports whether the value is derived from sensitive values.
+	Sensitive bool
+}
+
+// AttributeValuesResponse is the interface used to communicate via RPC.
+type AttributeValuesResponse struct {
+	Attributes []*EvaluatedAttribute
+	Err        error
+}
+
+// WalkResourceAttributeValues is the same as WalkResourceAttributes, but the host also evaluates each attribute
+// and passes the value to the walker function, so rules that only check values need no EvaluateExpr round trip.
+// If the value is not statically known (e.g. it refers to unknown variables or fails to be evaluated), cty.DynamicVal is passed.
+func (c *Client) WalkResourceAttributeValues(resource, attributeName string, walker func(*hcl.Attribute, cty.Value) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s.*.%s` attribute values", resource, attributeName)
+
+	var response AttributeValuesResponse
+	if err := c.callShared("Plugin.AttributeValues", AttributesRequest{Resource: resource, AttributeName: attributeName}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, evaluated := range response.Attributes {
+		if c.ignored(evaluated.Attribute.Range) {
+			continue
+		}
+		val := cty.DynamicVal
+		if evaluated.Val != nil {
+			val = *evaluated.Val
+			c.setSensitive(evaluated.Attribute.Expr.Range(), evaluated.Sensitive)
+		}
+		if err := walker(evaluated.Attribute, val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountRequest is the interface used to communicate via RPC.
+type CountRequest struct {
+	Resource string
+	// BlockType is the type of nested blocks to count. If empty, resources are counted.
+	BlockType string
+}
+
+// CountResponse is the interface used to communicate via RPC.
+type CountResponse struct {
+	Count int
+	Err   error
+}
+
+// CountResources queries the host process for the number of resources of the passed type.
+// Only the number is transferred, so threshold rules do not need to fetch bodies just to count them.
+func (c *Client) CountResources(resource string) (int, error) {
+	c.logger.Printf("[DEBUG] Count `%s` resources", resource)
+	return c.count(CountRequest{Resource: resource})
+}
+
+// CountBlocks queries the host process for the number of nested blocks of the passed type in resources of the passed type.
+// If the resource type is empty, it counts top-level blocks of the type instead (e.g. CountBlocks("", "provider")).
+func (c *Client) CountBlocks(resource, blockType string) (int, error) {
+	c.logger.Printf("[DEBUG] Count `%s.*.%s` blocks", resource, blockType)
+	return c.count(CountRequest{Resource: resource, BlockType: blockType})
+}
+
+func (c *Client) count(req CountRequest) (int, error) {
+	var response CountResponse
+	if err := c.call("Plugin.Count", req, &response); err != nil {
+		return 0, err
+	}
+	if response.Err != nil {
+		return 0, response.Err
+	}
+	return response.Count, nil
+}
+
+// AttributeOrderRequest is the interface used to communicate via RPC.
+type AttributeOrderRequest struct {
+	Resource string
+}
+
+// AttributeOrderResponse is the interface used to communicate via RPC.
+type AttributeOrderResponse struct {
+	Orders []*AttributeOrder
+	Err    error
+}
+
+// WalkAttributeOrder queries the host process, receives the attributes of each resource of the passed type
+// in declaration order, and passes each to the walker function.
+func (c *Client) WalkAttributeOrder(resource string, walker func(*AttributeOrder) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s` attribute order", resource)
+
+	var response AttributeOrderResponse
+	if err := c.call("Plugin.AttributeOrder", AttributeOrderRequest{Resource: resource}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, order := range response.Orders {
+		if err := walker(order); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResourceAttributeNamesRequest is the interface used to communicate via RPC.
+type ResourceAttributeNamesRequest struct {
+	Resource string
+}
+
+// ResourceAttributeNamesResponse is the interface used to communicate via RPC.
+type ResourceAttributeNamesResponse struct {
+	Resources []*ResourceAttributeNames
+	Err       error
+}
+
+// GetResourceAttributeNames queries the host process for the attribute names present in each resource of the passed type.
+// Only names are transferred, so it is much cheaper than fetching attributes themselves.
+func (c *Client) GetResourceAttributeNames(resource string) ([]*ResourceAttributeNames, error) {
+	c.logger.Printf("[DEBUG] Get `%s` attribute names", resource)
+
+	var response ResourceAttributeNamesResponse
+	if err := c.call("Plugin.ResourceAttributeNames", ResourceAttributeNamesRequest{Resource: resource}, &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Resources, nil
+}
+
+// ResourcesRequest is the interface used to communicate via RPC.
+type ResourcesRequest struct {
+	Resource string
+}
+
+// ResourcesResponse is the interface used to communicate via RPC.
+// Bodies are not sent as they are. Sources has the source of each resource block in native syntax instead.
+type ResourcesResponse struct {
+	Resources []*Resource
+	Sources   [][]byte
+	Err       error
+}
+
+// WalkResources queries the host process, receives the resources of the passed type with their bodies,
+// and passes each to the walker function.
+// Use this if you need to decode the whole body, e.g. to check that an attribute is missing.
+// Resources in JSON syntax are not sent, as their bodies cannot be parsed again from the source.
+func (c *Client) WalkResources(resource string, walker func(*Resource) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s` resources", resource)
+
+	var response ResourcesResponse
+	if err := c.call("Plugin.Resources", ResourcesRequest{Resource: resource}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+	if len(response.Resources) != len(response.Sources) {
+		return fmt.Errorf("The host returned %d resources with %d sources", len(response.Resources), len(response.Sources))
+	}
+
+	for i, res := range response.Resources {
+		if diags := res.parseBody(response.Sources[i]); diags.HasErrors() {
+			return diags
+		}
+		if err := walker(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MetaArgumentsRequest is the interface used to communicate via RPC.
+type MetaArgumentsRequest struct {
+	Resource string
+}
+
+// MetaArgumentsResponse is the interface used to communicate via RPC.
+type MetaArgumentsResponse struct {
+	Resources []*MetaArguments
+	Err       error
+}
+
+// WalkResourceMetaArguments queries the host process, receives the meta-arguments (count, for_each, provider, depends_on, lifecycle)
+// of each resource of the passed type, and passes each to the walker function.
+// Depending on the host, meta-arguments are not returned by WalkResourceAttributes, so use this for rules about meta-argument usage.
+func (c *Client) WalkResourceMetaArguments(resource string, walker func(*MetaArguments) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s` meta-arguments", resource)
+
+	var response MetaArgumentsResponse
+	if err := c.call("Plugin.MetaArguments", MetaArgumentsRequest{Resource: resource}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, meta := range response.Resources {
+		if err := walker(meta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProvisionersRequest is the interface used to communicate via RPC.
+type ProvisionersRequest struct {
+	Resource string
+}
+
+// ProvisionersResponse is the interface used to communicate via RPC.
+type ProvisionersResponse struct {
+	Provisioners []*Provisioner
+	Err          error
+}
+
+// WalkResourceProvisioners queries the host process, receives the provisioners declared in resources of the passed type
+// together with the connections that apply to them, and passes each to the walker function.
+func (c *Client) WalkResourceProvisioners(resource string, walker func(*Provisioner) error) error {
+	c.logger.Printf("[DEBUG] Walk `%s` provisioners", resource)
+
+	var response ProvisionersResponse
+	if err := c.call("Plugin.Provisioners", ProvisionersRequest{Resource: resource}, &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, provisioner := range response.Provisioners {
+		if err := walker(provisioner); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LocalsResponse is the interface used to communicate via RPC.
+type LocalsResponse struct {
+	Locals []*hcl.Attribute
+	Err    error
+}
+
+// WalkLocals queries the host process, receives the local values declared in `locals` blocks,
+// and passes each name and expression to the walker function.
+// Expressions are transferred and parsed in the same way as WalkResourceAttributes.
+func (c *Client) WalkLocals(walker func(string, hcl.Expression) error) error {
+	c.logger.Printf("[DEBUG] Walk locals")
+
+	var response LocalsResponse
+	if err := c.call("Plugin.Locals", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, local := range response.Locals {
+		if err := walker(local.Name, local.Expr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VariablesResponse is the interface used to communicate via RPC.
+type VariablesResponse struct {
+	Variables []*Variable
+	Err       error
+}
+
+// WalkVariables queries the host process, receives the variable declarations (`variable` blocks) in the module,
+// and passes each to the walker function.
+func (c *Client) WalkVariables(walker func(*Variable) error) error {
+	c.logger.Printf("[DEBUG] Walk variables")
+
+	var response VariablesResponse
+	if err := c.call("Plugin.Variables", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, variable := range response.Variables {
+		if err := walker(variable); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OutputsResponse is the interface used to communicate via RPC.
+type OutputsResponse struct {
+	Outputs []*Output
+	Err     error
+}
+
+// WalkOutputs queries the host process, receives the output declarations (`output` blocks) in the module,
+// and passes each to the walker function.
+func (c *Client) WalkOutputs(walker func(*Output) error) error {
+	c.logger.Printf("[DEBUG] Walk outputs")
+
+	var response OutputsResponse
+	if err := c.call("Plugin.Outputs", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, output := range response.Outputs {
+		if err := walker(output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MovedBlocksResponse is the interface used to communicate via RPC.
+type MovedBlocksResponse struct {
+	Moved []*Moved
+	Err   error
+}
+
+// WalkMovedBlocks queries the host process, receives the `moved` blocks declared in the module,
+// and passes each to the walker function.
+func (c *Client) WalkMovedBlocks(walker func(*Moved) error) error {
+	c.logger.Printf("[DEBUG] Walk moved blocks")
+
+	var response MovedBlocksResponse
+	if err := c.call("Plugin.MovedBlocks", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, moved := range response.Moved {
+		if err := walker(moved); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportBlocksResponse is the interface used to communicate via RPC.
+type ImportBlocksResponse struct {
+	Imports []*Import
+	Err     error
+}
+
+// WalkImportBlocks queries the host process, receives the `import` blocks declared in the module,
+// and passes each to the walker function.
+func (c *Client) WalkImportBlocks(walker func(*Import) error) error {
+	c.logger.Printf("[DEBUG] Walk import blocks")
+
+	var response ImportBlocksResponse
+	if err := c.call("Plugin.ImportBlocks", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, imp := range response.Imports {
+		if err := walker(imp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ConditionsResponse is the interface used to communicate via RPC.
+type ConditionsResponse struct {
+	Conditions []*Condition
+	Err        error
+}
+
+// WalkConditions queries the host process, receives the custom conditions declared in the module
+// (`assert` blocks in `check` blocks, and `precondition`/`postcondition` blocks), and passes each to the walker function.
+// Condition expressions in native syntax are parsed again from the source, so rules can inspect operators.
+func (c *Client) WalkConditions(walker func(*Condition) error) error {
+	c.logger.Printf("[DEBUG] Walk conditions")
+
+	var response ConditionsResponse
+	if err := c.call("Plugin.Conditions", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, condition := range response.Conditions {
+		for _, attribute := range []*hcl.Attribute{condition.Condition, condition.ErrorMessage} {
+			if attribute == nil {
+				continue
+			}
+			if diags := unwireAttribute(attribute); diags.HasErrors() {
+				return diags
+			}
+		}
+		if err := walker(condition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unwireAttribute replaces the wire representation of the attribute's expression in native syntax with the parsed expression
+func unwireAttribute(attribute *hcl.Attribute) hcl.Diagnostics {
+	wired, ok := attribute.Expr.(*WireExpr)
+	if !ok || wired.Syntax != NativeSyntax {
+		return nil
+	}
+	native, diags := wired.native()
+	if diags.HasErrors() {
+		return diags
+	}
+	attribute.Expr = native
+	return nil
+}
+
+// TerraformSettingsResponse is the interface used to communicate via RPC.
+type TerraformSettingsResponse struct {
+	Settings []*TerraformSettings
+	Err      error
+}
+
+// WalkTerraformSettings queries the host process, receives the settings (`terraform` blocks) declared in the module,
+// and passes each to the walker function. A module can have multiple terraform blocks.
+func (c *Client) WalkTerraformSettings(walker func(*TerraformSettings) error) error {
+	c.logger.Printf("[DEBUG] Walk terraform settings")
+
+	var response TerraformSettingsResponse
+	if err := c.call("Plugin.TerraformSettings", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, settings := range response.Settings {
+		if err := walker(settings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BackendResponse is the interface used to communicate via RPC.
+type BackendResponse struct {
+	Backend *Backend
+	Err     error
+}
+
+// Backend queries the host process and receives the backend configured in the module.
+// It returns nil if no backend is configured. If declared multiple times, the first one is returned.
+func (c *Client) Backend() (*Backend, error) {
+	c.logger.Printf("[DEBUG] Get backend")
+
+	var response BackendResponse
+	if err := c.call("Plugin.Backend", new(interface{}), &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Backend, nil
+}
+
+// ModuleCallsResponse is the interface used to communicate via RPC.
+type ModuleCallsResponse struct {
+	ModuleCalls []*ModuleCall
+	Err         error
+}
+
+// WalkModuleCalls queries the host process, receives the module calls (`module` blocks) declared in the module,
+// and passes each to the walker function.
+func (c *Client) WalkModuleCalls(walker func(*ModuleCall) error) error {
+	c.logger.Printf("[DEBUG] Walk module calls")
+
+	var response ModuleCallsResponse
+	if err := c.call("Plugin.ModuleCalls", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, call := range response.ModuleCalls {
+		if err := walker(call); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// AttributesRequest is the interface used to communicate via RPC.
-type AttributesRequest struct {
-	Resource      string
-	AttributeName string
+// VariableFlowsRequest is the interface used to communicate via RPC.
+type VariableFlowsRequest struct {
+	Module string
 }
 
-// AttributesResponse is the interface used to communicate via RPC.
-type AttributesResponse struct {
-	Attributes []*hcl.Attribute
-	Err        error
+// VariableFlowsResponse is the interface used to communicate via RPC.
+type VariableFlowsResponse struct {
+	Flows []*VariableFlow
+	Err   error
 }
 
-// WalkResourceAttributes queries the host process, receives a list of attributes that match the conditions,
-// and passes each to the walker function.
-func (c *Client) WalkResourceAttributes(resource, attributeName string, walker func(*hcl.Attribute) error) error {
-	log.Printf("[DEBUG] Walk `%s.*.%s` attribute", resource, attributeName)
+// GetVariableFlows queries the host process for the flows of the arguments of the passed module call into the child module.
+// Each flow has the variable that the argument feeds and where the variable is used in the child module.
+// If the host has not loaded the child module (e.g. not installed), nil is returned without an error.
+func (c *Client) GetVariableFlows(module string) ([]*VariableFlow, error) {
+	c.logger.Printf("[DEBUG] Get variable flows of `module.%s`", module)
+
+	var response VariableFlowsResponse
+	if err := c.call("Plugin.VariableFlows", VariableFlowsRequest{Module: module}, &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Flows, nil
+}
+
+// ProviderConfigsResponse is the interface used to communicate via RPC.
+type ProviderConfigsResponse struct {
+	Providers []*ProviderConfig
+	Err       error
+}
+
+// WalkProviderConfigs queries the host process, receives the provider configurations (`provider` blocks)
+// declared in the module, and passes each to the walker function.
+// Attribute expressions are sent as they are, so rules can flag hardcoded credentials.
+func (c *Client) WalkProviderConfigs(walker func(*ProviderConfig) error) error {
+	c.logger.Printf("[DEBUG] Walk provider configs")
 
-	var response AttributesResponse
-	if err := c.rpcClient.Call("Plugin.Attributes", AttributesRequest{Resource: resource, AttributeName: attributeName}, &response); err != nil {
+	var response ProviderConfigsResponse
+	if err := c.call("Plugin.ProviderConfigs", new(interface{}), &response); err != nil {
 		return err
 	}
 	if response.Err != nil {
 		return response.Err
 	}
 
-	for _, attribute := range response.Attributes {
-		if err := walker(attribute); err != nil {
+	for _, provider := range response.Providers {
+		if err := walker(provider); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FunctionCallsResponse is the interface used to communicate via RPC.
+type FunctionCallsResponse struct {
+	Calls []*FunctionCall
+	Err   error
+}
+
+// GetFunctionCalls queries the host process for all function calls used in the configuration.
+// Only names and ranges are transferred, so rules about function usage need only one request.
+func (c *Client) GetFunctionCalls() ([]*FunctionCall, error) {
+	c.logger.Printf("[DEBUG] Get function calls")
+
+	var response FunctionCallsResponse
+	if err := c.call("Plugin.FunctionCalls", new(interface{}), &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Calls, nil
+}
+
+// ExpressionsResponse is the interface used to communicate via RPC.
+type ExpressionsResponse struct {
+	Expressions []*WireExpr
+	Err         error
+}
+
+// WalkExpressions queries the host process, receives the expressions of all attributes in the module,
+// including attributes in nested blocks, locals and outputs, and passes each to the walker function in source order.
+// Expressions in native syntax are passed as hclsyntax.Expression, so use VisitExpr to visit their descendants.
+func (c *Client) WalkExpressions(walker func(hcl.Expression) error) error {
+	c.logger.Printf("[DEBUG] Walk expressions")
+
+	var response ExpressionsResponse
+	if err := c.call("Plugin.Expressions", new(interface{}), &response); err != nil {
+		return err
+	}
+	if response.Err != nil {
+		return response.Err
+	}
+
+	for _, wired := range response.Expressions {
+		var expr hcl.Expression = wired
+		if wired.Syntax == NativeSyntax {
+			native, diags := wired.native()
+			if diags.HasErrors() {
+				return diags
+			}
+			expr = native
+		}
+		if err := walker(expr); err != nil {
 			return err
 		}
 	}
@@ -56,30 +1160,168 @@ func (c *Client) WalkResourceAttributes(resource, attributeName string, walker f
 	return nil
 }
 
+// FilesRequest is the interface used to communicate via RPC.
+type FilesRequest struct {
+	Pattern string
+}
+
+// FilesResponse is the interface used to communicate via RPC.
+type FilesResponse struct {
+	Filenames []string
+	Err       error
+}
+
+// GetFiles queries the host process for the configuration filenames matching the passed glob pattern
+// (e.g. `*.tfvars`, `modules/**/*.tf`). See MatchGlob for the pattern syntax.
+// Filenames are sorted and relative to the module root, so file-layout rules need not touch the filesystem.
+func (c *Client) GetFiles(pattern string) ([]string, error) {
+	c.logger.Printf("[DEBUG] Get files matching `%s`", pattern)
+
+	var response FilesResponse
+	if err := c.call("Plugin.Files", FilesRequest{Pattern: pattern}, &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Filenames, nil
+}
+
+// VariableFilesResponse is the interface used to communicate via RPC.
+type VariableFilesResponse struct {
+	Files []*VariableFile
+	Err   error
+}
+
+// GetVariableFiles queries the host process for the variable definitions files (*.tfvars, *.tfvars.json)
+// with their assignments. Files are sorted by filename.
+func (c *Client) GetVariableFiles() ([]*VariableFile, error) {
+	c.logger.Printf("[DEBUG] Get variable files")
+
+	var response VariableFilesResponse
+	if err := c.call("Plugin.VariableFiles", new(interface{}), &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	return response.Files, nil
+}
+
+// ModuleContentRequest is the interface used to communicate via RPC.
+type ModuleContentRequest struct {
+	Schema *hcl.BodySchema
+}
+
+// ModuleContentResponse is the interface used to communicate via RPC.
+// Block bodies are not sent as they are. Sources has the source of each block in native syntax instead.
+type ModuleContentResponse struct {
+	Content *hcl.BodyContent
+	Sources [][]byte
+	Err     error
+}
+
+// GetModuleContent queries the host process for the content of the module that matches the passed schema.
+// Multiple block types (e.g. resource, data, module, provider, variable, output) can be fetched in a single request.
+// Block bodies are returned as is, so you can decode nested blocks and attributes with the body's Content method.
+func (c *Client) GetModuleContent(schema *hcl.BodySchema) (*hcl.BodyContent, error) {
+	c.logger.Printf("[DEBUG] Get module content")
+
+	var response ModuleContentResponse
+	if err := c.call("Plugin.ModuleContent", ModuleContentRequest{Schema: schema}, &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+	if len(response.Content.Blocks) != len(response.Sources) {
+		return nil, fmt.Errorf("The host returned %d blocks with %d sources", len(response.Content.Blocks), len(response.Sources))
+	}
+	for i, block := range response.Content.Blocks {
+		if diags := parseBlockBody(block, response.Sources[i]); diags.HasErrors() {
+			return nil, diags
+		}
+	}
+
+	return response.Content, nil
+}
+
 // EvalExprRequest is the interface used to communicate via RPC.
 type EvalExprRequest struct {
 	Expr hcl.Expression
-	Ret  interface{}
+	// Ret is the value that the result will be reflected in. It is nil if the caller does not need the converted value.
+	Ret interface{}
 }
 
 // EvalExprResponse is the interface used to communicate with RPC.
 type EvalExprResponse struct {
 	Val cty.Value
-	Err error
+	// UnknownVal is the value encoded by MarshalValue. It is set instead of Val if the value is not wholly known
+	// or has dynamic types (e.g. `null`), which gob cannot encode.
+	UnknownVal []byte
+	// Sensitive reports whether the value is derived from sensitive values.
+	Sensitive bool
+	Err       error
 }
 
 // EvaluateExpr queries the host process for the result of evaluating the value of the passed expression
 // and reflects it as the value of the second argument based on that.
+// The second argument can be a pointer to any type supported by gocty, including maps, slices and structs with `cty` tags.
+// Values are converted into the type implied by it (e.g. objects into maps), so `map[string]string` works for tags.
+// If the second argument is *cty.Value, the evaluated value is set as is without conversion.
+// In this case, unknown values are also set instead of returning UnknownValueError,
+// so rules can inspect maps, tuples and unknowns themselves.
+//
+// If the value is unknown (e.g. it refers to computed attributes or variables without values), UnknownValueError is returned
+// as a warning unless the second argument is *cty.Value. Pass the error to EnsureNoError to skip it, or check it with IsUnknownValueError.
+//
+// Terraform built-in functions (e.g. `lower(var.name)`) are available in the expression.
+// If the expression cannot be evaluated statically (e.g. it refers to resource attributes), UnevaluableError is returned
+// as a warning, so rules can skip it gracefully. EvaluationError means that the evaluation itself failed.
+// If a decoder is registered for the type of the second argument by RegisterDecoder, the value is decoded with it.
 func (c *Client) EvaluateExpr(expr hcl.Expression, ret interface{}) error {
-	var response EvalExprResponse
-	var err error
+	if _, exists := decoderFor(ret); exists {
+		var val cty.Value
+		if err := c.EvaluateExpr(expr, &val); err != nil {
+			return err
+		}
+		_, err := DecodeValue(val, ret, expr.Range())
+		return err
+	}
 
-	if err := c.rpcClient.Call("Plugin.EvalExpr", EvalExprRequest{Expr: expr, Ret: ret}, &response); err != nil {
+	sent, err := c.sendExpr(expr)
+	if err != nil {
+		return err
+	}
+
+	var response EvalExprResponse
+	req := EvalExprRequest{Expr: sent, Ret: ret}
+	if !sendableRet(ret) {
+		// Maps, slices, structs and cty.Value cannot be sent as Ret, so the value is converted in the client.
+		req.Ret = nil
+	}
+	if err := c.call("Plugin.EvalExpr", req, &response); err != nil {
 		return err
 	}
 	if response.Err != nil {
 		return response.Err
 	}
+	c.setSensitive(expr.Range(), response.Sensitive)
+	if response.UnknownVal != nil {
+		response.Val, err = UnmarshalValue(response.UnknownVal)
+		if err != nil {
+			return err
+		}
+	}
+	if req.Ret == nil {
+		if err := reflectValue(response.Val, ret, expr.Range()); err != nil {
+			c.logger.Printf("[ERROR] %s", err)
+			return err
+		}
+		return nil
+	}
 
 	err = gocty.FromCtyValue(response.Val, ret)
 	if err != nil {
@@ -93,12 +1335,194 @@ func (c *Client) EvaluateExpr(expr hcl.Expression, ret interface{}) error {
 			),
 			Cause: err,
 		}
-		log.Printf("[ERROR] %s", err)
+		c.logger.Printf("[ERROR] %s", err)
 		return err
 	}
 	return nil
 }
 
+// IsNullExpr queries the host process whether the passed expression evaluates to null (e.g. `attribute = null`).
+// The value is received as is without conversion, so it does not fail like conversion into Go types does.
+// Unknown values are not null. Errors are the same as EvaluateExpr (e.g. UnevaluableError).
+func (c *Client) IsNullExpr(expr hcl.Expression) (bool, error) {
+	var val cty.Value
+	if err := c.EvaluateExpr(expr, &val); err != nil {
+		return false, err
+	}
+	return val.IsNull(), nil
+}
+
+// sendableRet returns true if ret can be sent via RPC as EvalExprRequest.Ret.
+// gob can send interface values only of registered types, so only pointers to predeclared types (e.g. *string, *int) are sent.
+func sendableRet(ret interface{}) bool {
+	ty := reflect.TypeOf(ret)
+	if ty == nil || ty.Kind() != reflect.Ptr {
+		return false
+	}
+	return ty.Elem().Name() != "" && ty.Elem().PkgPath() == ""
+}
+
+// IsSensitive reports whether the value of the passed expression is sensitive.
+// If the expression has already been evaluated, the result of the evaluation is reused.
+func (c *Client) IsSensitive(expr hcl.Expression) (bool, error) {
+	c.mu.Lock()
+	sensitive, exists := c.sensitives[expr.Range()]
+	c.mu.Unlock()
+	if exists {
+		return sensitive, nil
+	}
+
+	sent, err := c.sendExpr(expr)
+	if err != nil {
+		return false, err
+	}
+	// Ret is nil because only the sensitivity is needed, not the converted value.
+	var response EvalExprResponse
+	if err := c.call("Plugin.EvalExpr", EvalExprRequest{Expr: sent, Ret: nil}, &response); err != nil {
+		return false, err
+	}
+	if response.Err != nil {
+		return false, response.Err
+	}
+
+	c.setSensitive(expr.Range(), response.Sensitive)
+	return response.Sensitive, nil
+}
+
+// sendExpr returns the expression to be sent via RPC. Expressions in native syntax that cannot be encoded by gob
+// (e.g. operators in bodies parsed from the source) are sent as the wire representation built from the source in the host.
+func (c *Client) sendExpr(expr hcl.Expression) (hcl.Expression, error) {
+	if sendableExpr(expr) {
+		return expr, nil
+	}
+	src, err := c.ExprSource(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &WireExpr{
+		Version:    WireExprVersion,
+		Syntax:     NativeSyntax,
+		Encoding:   UTF8Encoding,
+		Src:        []byte(src),
+		SrcRange:   expr.Range(),
+		Traversals: expr.Variables(),
+	}, nil
+}
+
+// setSensitive records the sensitivity of the expression with the passed range reported by the host
+func (c *Client) setSensitive(rng hcl.Range, sensitive bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sensitives[rng] = sensitive
+}
+
+// SourceRequest is the interface used to communicate via RPC.
+type SourceRequest struct {
+	Range hcl.Range
+}
+
+// SourceResponse is the interface used to communicate via RPC.
+type SourceResponse struct {
+	Src []byte
+	Err error
+}
+
+// ExprSource queries the host process for the source text of the passed expression.
+// The source is taken from the files loaded by the host, so rules that only need the raw text
+// (e.g. for messages or regexp checks) do not need to read files themselves, which may differ from what the host inspects.
+func (c *Client) ExprSource(expr hcl.Expression) (string, error) {
+	c.logger.Printf("[DEBUG] Get source of `%s`", expr.Range())
+
+	var response SourceResponse
+	if err := c.call("Plugin.Source", SourceRequest{Range: expr.Range()}, &response); err != nil {
+		return "", err
+	}
+	if response.Err != nil {
+		return "", response.Err
+	}
+
+	return string(response.Src), nil
+}
+
+// ProviderConfigRequest is the interface used to communicate via RPC.
+type ProviderConfigRequest struct {
+	Provider string
+	Name     string
+	// Ret is the value that the result will be reflected in. It is nil if the caller needs the value as is.
+	Ret interface{}
+}
+
+// ProviderConfigResponse is the interface used to communicate via RPC.
+type ProviderConfigResponse struct {
+	Val cty.Value
+	// UnknownVal is the value encoded by MarshalValue. It is set instead of Val if the value is not wholly known
+	// or has dynamic types (e.g. `null`), which gob cannot encode.
+	UnknownVal []byte
+	Exists     bool
+	Err        error
+}
+
+// GetProviderConfigValue queries the host process for the value of the attribute in the provider configuration,
+// and reflects it as the value of the third argument. The value is evaluated by the host.
+// The provider is the name with an optional alias (e.g. "azurerm" or "aws.west"), and the name is a dotted path
+// whose leading segments are nested block types (e.g. "features.key_vault.purge_soft_delete_on_destroy").
+// It returns false without an error if the provider configuration or the attribute is not declared,
+// so rules can gate themselves on provider settings cheaply.
+func (c *Client) GetProviderConfigValue(provider, name string, ret interface{}) (bool, error) {
+	c.logger.Printf("[DEBUG] Get `%s` provider config `%s`", provider, name)
+
+	req := ProviderConfigRequest{Provider: provider, Name: name, Ret: ret}
+	if !sendableRet(ret) {
+		// Maps, slices, structs and cty.Value cannot be sent as Ret, so the value is converted in the client.
+		req.Ret = nil
+	}
+
+	var response ProviderConfigResponse
+	if err := c.call("Plugin.ProviderConfig", req, &response); err != nil {
+		return false, err
+	}
+	if response.Err != nil {
+		return false, response.Err
+	}
+	if !response.Exists {
+		return false, nil
+	}
+
+	val := response.Val
+	if response.UnknownVal != nil {
+		var err error
+		val, err = UnmarshalValue(response.UnknownVal)
+		if err != nil {
+			return false, err
+		}
+	}
+	if _, ok := ret.(*cty.Value); !ok && !val.IsWhollyKnown() {
+		return false, Error{
+			Code:    UnknownValueError,
+			Level:   WarningLevel,
+			Message: fmt.Sprintf("Unknown value found in provider.%s.%s", provider, name),
+		}
+	}
+	if _, ok := ret.(*cty.Value); !ok && req.Ret == nil {
+		if ty, err := gocty.ImpliedType(ret); err == nil {
+			if converted, err := convert.Convert(val, ty); err == nil {
+				val = converted
+			}
+		}
+	}
+	if err := gocty.FromCtyValue(val, ret); err != nil {
+		err := &Error{
+			Code:    TypeMismatchError,
+			Level:   ErrorLevel,
+			Message: fmt.Sprintf("Invalid type of `%s` in `%s` provider config", name, provider),
+			Cause:   err,
+		}
+		c.logger.Printf("[ERROR] %s", err)
+		return false, err
+	}
+	return true, nil
+}
+
 // EmitIssueRequest is the interface used to communicate via RPC.
 type EmitIssueRequest struct {
 	Rule     *RuleObject
@@ -110,32 +1534,273 @@ type EmitIssueRequest struct {
 // EmitIssue emits attributes to build the issue to the host process
 // Note that the passed rule need to be converted to generic objects
 // because the custom structure defined in the plugin cannot be sent via RPC.
+// If the rule implements DeduplicatedRule, identical issues are emitted only once.
 func (c *Client) EmitIssue(rule Rule, message string, location hcl.Range, meta Metadata) error {
+	if deduplicated, ok := rule.(DeduplicatedRule); ok && deduplicated.DeduplicateIssues() {
+		key := issueKey{rule: rule.Name(), message: message, location: location}
+		c.mu.Lock()
+		emitted := c.emitted[key]
+		c.emitted[key] = true
+		c.mu.Unlock()
+		if emitted {
+			c.logger.Printf("[DEBUG] Skip duplicate issue of `%s` rule at %s", rule.Name(), location)
+			return nil
+		}
+	}
+
+	if meta.Fix != nil {
+		fix := *meta.Fix
+		fix.Rule = rule.Name()
+		meta.Fix = &fix
+	}
+
 	req := &EmitIssueRequest{
-		Rule:     newObjectFromRule(rule),
+		Rule:     newObjectFromRule(rule, c.linker),
 		Message:  message,
 		Location: location,
 		Meta:     meta,
 	}
-	if err := c.rpcClient.Call("Plugin.EmitIssue", &req, new(interface{})); err != nil {
+	if err := c.call("Plugin.EmitIssue", req, new(interface{})); err != nil {
+		return err
+	}
+	return nil
+}
+
+// EmitRecordRequest is the interface used to communicate via RPC.
+type EmitRecordRequest struct {
+	Rule     *RuleObject
+	Value    cty.Value
+	Location hcl.Range
+}
+
+// EmitRecord emits a record of the rule to the host process in query mode.
+// Unlike issues, records are data (e.g. an inventory entry) and are not affected by annotations.
+// Records are not counted against the call budget.
+func (c *Client) EmitRecord(rule Rule, value cty.Value, location hcl.Range) error {
+	req := &EmitRecordRequest{
+		Rule:     newObjectFromRule(rule, c.linker),
+		Value:    value,
+		Location: location,
+	}
+	if err := c.call("Plugin.EmitRecord", req, new(interface{})); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ProgressRequest is the interface used to communicate via RPC.
+type ProgressRequest struct {
+	Rule    *RuleObject
+	Percent int
+	Message string
+}
+
+// ReportProgress sends a progress update of the rule to the host process, so that interactive runs can show the status
+// during long checks. Percent is clamped to 100. Pass a negative value if the completion cannot be measured.
+// Progress updates are not counted against the call budget.
+func (c *Client) ReportProgress(rule Rule, percent int, message string) error {
+	if percent > 100 {
+		percent = 100
+	}
+	if percent < 0 {
+		percent = -1
+	}
+
+	req := &ProgressRequest{
+		Rule:    newObjectFromRule(rule, c.linker),
+		Percent: percent,
+		Message: message,
+	}
+	if err := c.call("Plugin.Progress", req, new(interface{})); err != nil {
 		return err
 	}
 	return nil
 }
 
+// RunMetadataResponse is the interface used to communicate via RPC.
+type RunMetadataResponse struct {
+	Metadata *RunMetadata
+	Err      error
+}
+
+// RunMetadata queries the host process for the metadata about the current run.
+// The metadata does not change during a run, so the result is cached after the first query.
+func (c *Client) RunMetadata() (*RunMetadata, error) {
+	c.mu.Lock()
+	metadata := c.metadata
+	c.mu.Unlock()
+	if metadata != nil {
+		return metadata, nil
+	}
+
+	var response RunMetadataResponse
+	if err := c.call("Plugin.RunMetadata", new(interface{}), &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadata = response.Metadata
+	return c.metadata, nil
+}
+
+// Clock returns the Clock of the run based on the metadata provided by the host process.
+// It is frozen if the host fixes the time, so use it instead of time.Now for reproducible results.
+func (c *Client) Clock() (Clock, error) {
+	metadata, err := c.RunMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return NewClock(metadata.FixedTime), nil
+}
+
+// Rand returns a random number generator for the passed rule seeded by the host process (e.g. for sampling large configurations).
+// Each call returns a new generator that yields the same sequence, so call it once per check.
+func (c *Client) Rand(rule Rule) (*rand.Rand, error) {
+	metadata, err := c.RunMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return NewRand(metadata.Seed, rule.Name()), nil
+}
+
+// Context returns the context of the rule being checked. It is canceled when the rule finishes or exceeds the call budget,
+// so pass it to long-running operations (e.g. API calls) to stop them early.
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// ModulePathResponse is the interface used to communicate via RPC.
+type ModulePathResponse struct {
+	Path ModulePath
+	Err  error
+}
+
+// ModulePath queries the host process for the call path of the module being inspected.
+// It allows rules to differentiate the root module and child modules, and include the module context in messages.
+// The path does not change during a check, so the result is cached after the first query.
+func (c *Client) ModulePath() (ModulePath, error) {
+	c.mu.Lock()
+	path, fetched := c.modulePath, c.modulePathFetched
+	c.mu.Unlock()
+	if fetched {
+		return path, nil
+	}
+
+	var response ModulePathResponse
+	if err := c.call("Plugin.ModulePath", new(interface{}), &response); err != nil {
+		return nil, err
+	}
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.modulePath = response.Path
+	c.modulePathFetched = true
+	return c.modulePath, nil
+}
+
+// ModuleName returns the name of the module call of the module being inspected.
+// It is empty for the root module.
+func (c *Client) ModuleName() (string, error) {
+	path, err := c.ModulePath()
+	if err != nil {
+		return "", err
+	}
+	return path.Name(), nil
+}
+
+// IsEmptyResponse is the interface used to communicate via RPC.
+type IsEmptyResponse struct {
+	Empty bool
+	Err   error
+}
+
+// IsEmpty queries the host process whether the module being inspected has no Terraform files (*.tf, *.tf.json).
+// An empty module is not an error. Walkers invoke nothing and queries return empty results,
+// so rules do not need to check this, but it allows them to skip expensive work (e.g. API calls) up front.
+// The result does not change during a check, so it is cached after the first query.
+func (c *Client) IsEmpty() (bool, error) {
+	c.mu.Lock()
+	empty, fetched := c.empty, c.emptyFetched
+	c.mu.Unlock()
+	if fetched {
+		return empty, nil
+	}
+
+	var response IsEmptyResponse
+	if err := c.call("Plugin.IsEmpty", new(interface{}), &response); err != nil {
+		return false, err
+	}
+	if response.Err != nil {
+		return false, response.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.empty = response.Empty
+	c.emptyFetched = true
+	return c.empty, nil
+}
+
+// EnvRequest is the interface used to communicate via RPC.
+type EnvRequest struct {
+	Name string
+}
+
+// EnvResponse is the interface used to communicate via RPC.
+type EnvResponse struct {
+	Value  string
+	Exists bool
+	Err    error
+}
+
+// LookupEnv queries the host process for the value of the environment variable (e.g. AWS_REGION, TF_VAR_name).
+// Use this instead of os.Getenv, as the plugin process may not share the environment with the host.
+// Hosts only expose allow-listed variables, so a variable can be reported as not existing even if it is set.
+func (c *Client) LookupEnv(name string) (string, bool, error) {
+	c.logger.Printf("[DEBUG] Lookup `%s` environment variable", name)
+
+	var response EnvResponse
+	if err := c.call("Plugin.Env", EnvRequest{Name: name}, &response); err != nil {
+		return "", false, err
+	}
+	if response.Err != nil {
+		return "", false, response.Err
+	}
+
+	return response.Value, response.Exists, nil
+}
+
+// IsOffline reports whether the user runs in offline mode.
+// Rules must not call external services (e.g. cloud provider APIs) if this is true.
+func (c *Client) IsOffline() bool {
+	return c.offline
+}
+
 // EnsureNoError is a helper for processing when no error occurs
 // This function skips processing without returning an error to the caller when the error is warning
+// (e.g. UnknownValueError for references to unknown values, UnevaluableError). Wrapped errors are also checked.
 func (*Client) EnsureNoError(err error, proc func() error) error {
 	if err == nil {
 		return proc()
 	}
 
-	if appErr, ok := err.(Error); ok {
+	var appErr Error
+	if errors.As(err, &appErr) {
 		switch appErr.Level {
 		case WarningLevel:
 			return nil
 		case ErrorLevel:
-			return appErr
+			return err
 		default:
 			panic(appErr)
 		}
@@ -0,0 +1,37 @@
+package tflint
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// Clock is the source of the current time for rules.
+// Use Runner.Clock instead of time.Now, so that rules embedding timestamps are reproducible in tests and across runs.
+type Clock interface {
+	Now() time.Time
+}
+
+// NewClock returns a Clock frozen at the passed time. If the time is zero, a Clock returning the system time is returned.
+func NewClock(fixed time.Time) Clock {
+	if fixed.IsZero() {
+		return systemClock{}
+	}
+	return fixedClock(fixed)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+// NewRand returns a random number generator for the rule with the passed name.
+// The rule name is mixed into the seed, so the sequence of a rule does not depend on other rules or the order of checks.
+func NewRand(seed int64, rule string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(rule))
+	return rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+}
@@ -0,0 +1,38 @@
+package tflint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_NewClock(t *testing.T) {
+	fixed := time.Date(2020, 5, 24, 0, 0, 0, 0, time.UTC)
+	if now := NewClock(fixed).Now(); !now.Equal(fixed) {
+		t.Fatalf("Expected the fixed time, but got %s", now)
+	}
+
+	before := time.Now()
+	if now := NewClock(time.Time{}).Now(); now.Before(before) {
+		t.Fatalf("Expected the system time, but got %s", now)
+	}
+}
+
+func Test_NewRand(t *testing.T) {
+	sequence := func(seed int64, rule string) []int {
+		rnd := NewRand(seed, rule)
+		return []int{rnd.Intn(1000), rnd.Intn(1000), rnd.Intn(1000)}
+	}
+
+	first := sequence(42, "rule_a")
+	if second := sequence(42, "rule_a"); !cmp.Equal(first, second) {
+		t.Fatalf("Expected the same sequence for the same seed, but got %v and %v", first, second)
+	}
+	if other := sequence(42, "rule_b"); cmp.Equal(first, other) {
+		t.Fatalf("Expected different sequences for different rules, but got %v", other)
+	}
+	if other := sequence(43, "rule_a"); cmp.Equal(first, other) {
+		t.Fatalf("Expected different sequences for different seeds, but got %v", other)
+	}
+}
@@ -0,0 +1,64 @@
+package tflint
+
+import (
+	"context"
+	"sync"
+)
+
+// WalkConcurrently calls fn for each index from 0 to count-1 with at most parallelism goroutines,
+// so that rules doing per-item lookups (e.g. API calls for each attribute) can parallelize safely.
+// Indexes are dispatched only when a goroutine is free, so slow calls apply backpressure instead of piling up.
+//
+// The context passed to fn is derived from Runner.Context. It is canceled when fn returns an error,
+// the rule exceeds the call budget, or the rule finishes, and no more indexes are dispatched after that.
+// The first error returned by fn is returned. If parallelism is less than 1, it is treated as 1.
+func WalkConcurrently(runner Runner, count int, parallelism int, fn func(ctx context.Context, i int) error) error {
+	parent := runner.Context()
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > count {
+		parallelism = count
+	}
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	indexes := make(chan int)
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < count; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return parent.Err()
+}
@@ -0,0 +1,145 @@
+package tflint
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_WalkConcurrently(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+
+	var running, maxRunning int32
+	var mu sync.Mutex
+	walked := map[int]bool{}
+	err := WalkConcurrently(client, 20, 3, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+
+		// Queries from multiple goroutines are safe.
+		if err := client.WalkResourceAttributes("aws_instance", "instance_type", func(*hcl.Attribute) error { return nil }); err != nil {
+			return err
+		}
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		walked[i] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(walked) != 20 {
+		t.Fatalf("Expected all 20 items to be walked, but got %d", len(walked))
+	}
+	if maxRunning > 3 {
+		t.Fatalf("Expected at most 3 concurrent calls, but got %d", maxRunning)
+	}
+}
+
+func Test_WalkConcurrently_annotation(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+	client.rule = "ignored_rule"
+
+	// All walks must wait for annotations fetched by the first one, and skip the ignored attribute.
+	var walked int32
+	err := WalkConcurrently(client, 20, 4, func(ctx context.Context, i int) error {
+		return client.WalkResourceAttributes("foo", "bar", func(*hcl.Attribute) error {
+			atomic.AddInt32(&walked, 1)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if walked != 0 {
+		t.Fatalf("Expected all attributes to be ignored, but %d attributes were walked", walked)
+	}
+}
+
+func Test_WalkConcurrently_error(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+
+	var calls int32
+	err := WalkConcurrently(client, 100, 2, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 3 {
+			return errors.New("failed")
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(10 * time.Millisecond):
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("Expected the error of the failed item, but got %v", err)
+	}
+	if calls >= 100 {
+		t.Fatalf("Expected the walk to stop early, but walked %d items", calls)
+	}
+}
+
+func Test_WalkConcurrently_budget(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+	client.budget = 5
+	client.ctx, client.cancel = context.WithCancel(context.Background())
+	defer client.cancel()
+
+	err := WalkConcurrently(client, 100, 4, func(ctx context.Context, i int) error {
+		return client.WalkResourceAttributes("aws_instance", "instance_type", func(*hcl.Attribute) error { return nil })
+	})
+	var budgetErr CallBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Expected the budget error, but got %v", err)
+	}
+	if client.Context().Err() == nil {
+		t.Fatal("Expected the rule context to be canceled")
+	}
+}
+
+func Test_WalkConcurrently_shared(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+	client.shared = map[string]interface{}{}
+
+	var mu sync.Mutex
+	ranges := map[hcl.Range]bool{}
+	err := WalkConcurrently(client, 20, 4, func(ctx context.Context, i int) error {
+		return client.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+			mu.Lock()
+			ranges[attribute.Range] = true
+			mu.Unlock()
+
+			// Modifying attributes does not affect other walks.
+			attribute.Range.Filename = "modified.tf"
+			attribute.Name = "modified"
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	for rng := range ranges {
+		if rng.Filename == "modified.tf" {
+			t.Fatalf("Expected shared attributes not to be modified, but got %s", rng)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+const (
+	// AssertCondition is an `assert` block in a `check` block
+	AssertCondition string = "assert"
+	// PreCondition is a `precondition` block in a `lifecycle` block of resources and data sources, or in an `output` block
+	PreCondition string = "precondition"
+	// PostCondition is a `postcondition` block in a `lifecycle` block of resources and data sources
+	PostCondition string = "postcondition"
+)
+
+// Condition is a custom condition declared in the module, such as check assertions and resource preconditions.
+type Condition struct {
+	// Kind is one of AssertCondition, PreCondition and PostCondition.
+	Kind string
+	// BlockType is the type of the top-level block containing the condition (e.g. "check", "resource").
+	BlockType string
+	// BlockLabels is the labels of the top-level block containing the condition (e.g. ["aws_instance", "web"]).
+	BlockLabels []string
+	DeclRange   hcl.Range
+	// Ranges is the set of ranges of the condition block.
+	Ranges BlockRanges
+
+	// Condition and ErrorMessage are nil if not declared.
+	Condition    *hcl.Attribute
+	ErrorMessage *hcl.Attribute
+}
+
+// conditionContainerSchema is the schema of top-level blocks that can contain conditions
+var conditionContainerSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "check", LabelNames: []string{"name"}},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "output", LabelNames: []string{"name"}},
+	},
+}
+
+// conditionSchema is the schema of condition blocks
+var conditionSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "condition"},
+		{Name: "error_message"},
+	},
+}
+
+// FindConditions returns all conditions declared in the passed file.
+func FindConditions(file *hcl.File) ([]*Condition, hcl.Diagnostics) {
+	content, _, diags := file.Body.PartialContent(conditionContainerSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := []*Condition{}
+	for _, container := range content.Blocks {
+		blocks, diags := conditionBlocks(container)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range blocks {
+			condition, _, diags := block.Body.PartialContent(conditionSchema)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+
+			ret = append(ret, &Condition{
+				Kind:         block.Type,
+				BlockType:    container.Type,
+				BlockLabels:  container.Labels,
+				DeclRange:    block.DefRange,
+				Ranges:       NewBlockRanges(block),
+				Condition:    condition.Attributes["condition"],
+				ErrorMessage: condition.Attributes["error_message"],
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// conditionBlocks returns condition blocks in the passed top-level block
+func conditionBlocks(container *hcl.Block) (hcl.Blocks, hcl.Diagnostics) {
+	switch container.Type {
+	case "check":
+		return nestedBlocks(container.Body, AssertCondition)
+	case "output":
+		return nestedBlocks(container.Body, PreCondition)
+	}
+
+	lifecycles, diags := nestedBlocks(container.Body, "lifecycle")
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	blocks := hcl.Blocks{}
+	for _, lifecycle := range lifecycles {
+		conditions, diags := nestedBlocks(lifecycle.Body, PreCondition, PostCondition)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		blocks = append(blocks, conditions...)
+	}
+	return blocks, nil
+}
+
+// nestedBlocks returns blocks of the passed types in the body
+func nestedBlocks(body hcl.Body, blockTypes ...string) (hcl.Blocks, hcl.Diagnostics) {
+	schema := &hcl.BodySchema{}
+	for _, blockType := range blockTypes {
+		schema.Blocks = append(schema.Blocks, hcl.BlockHeaderSchema{Type: blockType})
+	}
+
+	content, _, diags := body.PartialContent(schema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return content.Blocks, nil
+}
+
+// ErrorMessageText returns the error message. An empty string is returned if it is not declared or not a static string.
+func (c *Condition) ErrorMessageText() string {
+	return staticString(c.ErrorMessage)
+}
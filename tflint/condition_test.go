@@ -0,0 +1,87 @@
+package tflint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_FindConditions(t *testing.T) {
+	file, diags := hclsyntax.ParseConfig([]byte(`
+check "health" {
+  data "http" "health" {
+    url = "https://example.com/health"
+  }
+
+  assert {
+    condition     = data.http.health.status_code == 200
+    error_message = "The service is unhealthy."
+  }
+}
+
+resource "aws_instance" "web" {
+  lifecycle {
+    precondition {
+      condition     = data.aws_ami.ubuntu.architecture == "x86_64"
+      error_message = "The AMI must be for x86_64."
+    }
+
+    postcondition {
+      condition = self.public_dns != ""
+    }
+  }
+}
+
+data "aws_ami" "ubuntu" {
+  lifecycle {
+    postcondition {
+      condition     = self.tags["Component"] == "nomad-server"
+      error_message = local.message
+    }
+  }
+}
+
+output "endpoint" {
+  value = aws_instance.web.public_dns
+
+  precondition {
+    condition     = aws_instance.web.public_dns != ""
+    error_message = "The instance has no public DNS."
+  }
+}`), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	conditions, diags := FindConditions(file)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	got := []string{}
+	for _, condition := range conditions {
+		got = append(got, fmt.Sprintf(
+			"%s in %s %s at %s (condition: %t, error_message: %q)",
+			condition.Kind,
+			condition.BlockType,
+			strings.Join(condition.BlockLabels, "."),
+			condition.DeclRange,
+			condition.Condition != nil,
+			condition.ErrorMessageText(),
+		))
+	}
+	expected := []string{
+		`assert in check health at main.tf:7,3-9 (condition: true, error_message: "The service is unhealthy.")`,
+		`precondition in resource aws_instance.web at main.tf:15,5-17 (condition: true, error_message: "The AMI must be for x86_64.")`,
+		`postcondition in resource aws_instance.web at main.tf:20,5-18 (condition: true, error_message: "")`,
+		`postcondition in data aws_ami.ubuntu at main.tf:28,5-18 (condition: true, error_message: "")`,
+		`precondition in output endpoint at main.tf:38,3-15 (condition: true, error_message: "The instance has no public DNS.")`,
+	}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+}
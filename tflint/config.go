@@ -1,13 +1,109 @@
 package tflint
 
+import (
+	"bytes"
+	"encoding/gob"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
 // Config is a TFLint configuration applied to a plugin
 // At this time, it is not expected that each plugin will reference this directly
 type Config struct {
 	Rules map[string]*RuleConfig
+	// Only is a list of rule names to run in this session.
+	// If not empty, other rules are skipped regardless of the rule config.
+	Only []string
+	// Tags is a list of rule tags to run in this session.
+	// If not empty, only rules that have any of the tags are run.
+	Tags []string
+	// Offline reports whether the user runs without network connectivity.
+	// Rules that require the network are skipped, and other rules can check it via Runner.IsOffline.
+	Offline bool
+	// Query reports whether the host runs in query mode. Only rules that implement QueryRule are run
+	// among the rules selected by the rule config, Only, Tags and Offline.
+	Query bool
 }
 
 // RuleConfig is a TFLint's rule config
 type RuleConfig struct {
 	Name    string
 	Enabled bool
+	// Options are the rule-specific options, i.e. the attributes of the rule block evaluated by the host. It may be nil.
+	// Options are sent as decoded values instead of an hcl.Body, because bodies (e.g. in JSON syntax) cannot be sent via RPC.
+	// Use DecodeRuleOptions to build them from the rule block.
+	Options map[string]cty.Value
+}
+
+// ruleConfigWire is the wire representation of RuleConfig.
+// Options are encoded with MarshalValue because gob cannot encode null and unknown values.
+type ruleConfigWire struct {
+	Name    string
+	Enabled bool
+	Options []byte
+}
+
+// GobEncode encodes the rule config with its options
+func (c *RuleConfig) GobEncode() ([]byte, error) {
+	wire := ruleConfigWire{Name: c.Name, Enabled: c.Enabled}
+	if c.Options != nil {
+		options, err := MarshalValue(cty.ObjectVal(c.Options))
+		if err != nil {
+			return nil, err
+		}
+		wire.Options = options
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(wire); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes the rule config encoded by GobEncode
+func (c *RuleConfig) GobDecode(src []byte) error {
+	var wire ruleConfigWire
+	if err := gob.NewDecoder(bytes.NewReader(src)).Decode(&wire); err != nil {
+		return err
+	}
+
+	c.Name = wire.Name
+	c.Enabled = wire.Enabled
+	c.Options = nil
+	if wire.Options != nil {
+		options, err := UnmarshalValue(wire.Options)
+		if err != nil {
+			return err
+		}
+		c.Options = options.AsValueMap()
+		if c.Options == nil {
+			c.Options = map[string]cty.Value{}
+		}
+	}
+	return nil
+}
+
+// DecodeRuleOptions evaluates the attributes of the rule block as rule options.
+// The evaluation context is typically nil because the rule config cannot refer to Terraform configurations.
+func DecodeRuleOptions(body hcl.Body, ctx *hcl.EvalContext) (map[string]cty.Value, hcl.Diagnostics) {
+	attributes, diags := body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	options := map[string]cty.Value{}
+	for name, attribute := range attributes {
+		val, valDiags := attribute.Expr.Value(ctx)
+		diags = diags.Extend(valDiags)
+		if valDiags.HasErrors() {
+			continue
+		}
+		options[name] = val
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return options, diags
 }
@@ -0,0 +1,58 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_RuleConfig_gob(t *testing.T) {
+	// Bodies in JSON syntax cannot be sent via RPC, so options are sent as decoded values.
+	file, diags := json.Parse([]byte(`{"keys": ["Environment", "Owner"], "policy": null}`), ".tflint.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	options, diags := DecodeRuleOptions(file.Body, nil)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	config := &Config{Rules: map[string]*RuleConfig{
+		"with_options":    {Name: "with_options", Enabled: true, Options: options},
+		"without_options": {Name: "without_options", Enabled: false},
+	}}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(config); err != nil {
+		t.Fatal(err)
+	}
+	var got Config
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	withOptions := got.Rules["with_options"]
+	if withOptions == nil || withOptions.Name != "with_options" || !withOptions.Enabled {
+		t.Fatalf("Unexpected rule config: %#v", withOptions)
+	}
+	expected := map[string]cty.Value{
+		"keys":   cty.TupleVal([]cty.Value{cty.StringVal("Environment"), cty.StringVal("Owner")}),
+		"policy": cty.NullVal(cty.DynamicPseudoType),
+	}
+	if len(withOptions.Options) != len(expected) {
+		t.Fatalf("Unexpected options: %#v", withOptions.Options)
+	}
+	for name, val := range expected {
+		if !withOptions.Options[name].RawEquals(val) {
+			t.Fatalf("Expected `%s` to be %#v, but got %#v", name, val, withOptions.Options[name])
+		}
+	}
+
+	withoutOptions := got.Rules["without_options"]
+	if withoutOptions == nil || withoutOptions.Enabled || withoutOptions.Options != nil {
+		t.Fatalf("Unexpected rule config: %#v", withoutOptions)
+	}
+}
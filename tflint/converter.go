@@ -0,0 +1,125 @@
+package tflint
+
+import (
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Converter transforms an evaluated value before it is reflected in the result.
+// Converters are only called with known and non-null values.
+type Converter func(cty.Value) (cty.Value, error)
+
+// EvaluateExprWith evaluates the passed expression, applies the converters in order,
+// and reflects the result as the value of ret in the same way as EvaluateExpr, including registered decoders.
+// Errors returned by converters are reported as TypeConversionError with the location of the expression.
+//
+// Example:
+//
+//	var policy map[string]string
+//	err := tflint.EvaluateExprWith(runner, attr.Expr, &policy, tflint.TrimSpace, tflint.JSONDecode)
+func EvaluateExprWith(runner Runner, expr hcl.Expression, ret interface{}, converters ...Converter) error {
+	var val cty.Value
+	if err := runner.EvaluateExpr(expr, &val); err != nil {
+		return err
+	}
+
+	for _, converter := range converters {
+		if !val.IsWhollyKnown() || val.IsNull() {
+			break
+		}
+		converted, err := converter(val)
+		if err != nil {
+			return Error{
+				Code:    TypeConversionError,
+				Level:   ErrorLevel,
+				Message: fmt.Sprintf("Failed to convert the value in %s:%d", expr.Range().Filename, expr.Range().Start.Line),
+				Cause:   err,
+			}
+		}
+		val = converted
+	}
+
+	if decoded, err := DecodeValue(val, ret, expr.Range()); decoded {
+		return err
+	}
+	return reflectValue(val, ret, expr.Range())
+}
+
+// reflectValue converts the passed value into the type implied by ret and reflects it in the same way as EvaluateExpr.
+// Unknown values are reported as UnknownValueError unless ret is *cty.Value.
+func reflectValue(val cty.Value, ret interface{}, rng hcl.Range) error {
+	if _, ok := ret.(*cty.Value); !ok {
+		if !val.IsWhollyKnown() {
+			return Error{
+				Code:    UnknownValueError,
+				Level:   WarningLevel,
+				Message: fmt.Sprintf("Unknown value found in %s:%d", rng.Filename, rng.Start.Line),
+			}
+		}
+		if ty, err := gocty.ImpliedType(ret); err == nil {
+			converted, err := convert.Convert(val, ty)
+			if err != nil {
+				return Error{
+					Code:    TypeMismatchError,
+					Level:   ErrorLevel,
+					Message: fmt.Sprintf("Invalid type expression in %s:%d", rng.Filename, rng.Start.Line),
+					Cause:   err,
+				}
+			}
+			val = converted
+		}
+	}
+	if err := gocty.FromCtyValue(val, ret); err != nil {
+		return Error{
+			Code:    TypeMismatchError,
+			Level:   ErrorLevel,
+			Message: fmt.Sprintf("Invalid type expression in %s:%d", rng.Filename, rng.Start.Line),
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+// TrimSpace removes leading and trailing white spaces from a string.
+func TrimSpace(val cty.Value) (cty.Value, error) {
+	return convertString(val, strings.TrimSpace)
+}
+
+// Lower converts a string to lowercase.
+func Lower(val cty.Value) (cty.Value, error) {
+	return convertString(val, strings.ToLower)
+}
+
+// Upper converts a string to uppercase.
+func Upper(val cty.Value) (cty.Value, error) {
+	return convertString(val, strings.ToUpper)
+}
+
+// JSONDecode parses a JSON string (e.g. a policy document) into the value of the implied type.
+func JSONDecode(val cty.Value) (cty.Value, error) {
+	str, err := convert.Convert(val, cty.String)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	buf := []byte(str.AsString())
+
+	ty, err := ctyjson.ImpliedType(buf)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(buf, ty)
+}
+
+func convertString(val cty.Value, f func(string) string) (cty.Value, error) {
+	str, err := convert.Convert(val, cty.String)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return cty.StringVal(f(str.AsString())), nil
+}
@@ -0,0 +1,78 @@
+package tflint
+
+import (
+	"errors"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_Converters(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Converter Converter
+		Value     cty.Value
+		Expected  cty.Value
+	}{
+		{
+			Name:      "trim space",
+			Converter: TrimSpace,
+			Value:     cty.StringVal("  t2.micro\n"),
+			Expected:  cty.StringVal("t2.micro"),
+		},
+		{
+			Name:      "lower",
+			Converter: Lower,
+			Value:     cty.StringVal("T2.Micro"),
+			Expected:  cty.StringVal("t2.micro"),
+		},
+		{
+			Name:      "upper",
+			Converter: Upper,
+			Value:     cty.StringVal("us-east-1"),
+			Expected:  cty.StringVal("US-EAST-1"),
+		},
+		{
+			Name:      "json decode",
+			Converter: JSONDecode,
+			Value:     cty.StringVal(`{"Version": "2012-10-17"}`),
+			Expected:  cty.ObjectVal(map[string]cty.Value{"Version": cty.StringVal("2012-10-17")}),
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.Converter(tc.Value)
+		if err != nil {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, err)
+		}
+		if !got.RawEquals(tc.Expected) {
+			t.Fatalf("Failed `%s` test: expected %#v, but got %#v", tc.Name, tc.Expected, got)
+		}
+	}
+}
+
+func Test_EvaluateExprWith(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+
+	expr, diags := hclsyntax.ParseExpression([]byte(`"1"`), "example.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	var ret int
+	if err := EvaluateExprWith(client, expr, &ret, TrimSpace, JSONDecode); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if ret != 1 {
+		t.Fatalf("Expected 1, but got %d", ret)
+	}
+
+	failed := func(cty.Value) (cty.Value, error) { return cty.NilVal, errors.New("failed") }
+	err := EvaluateExprWith(client, expr, &ret, failed)
+	if appErr, ok := err.(Error); !ok || appErr.Code != TypeConversionError {
+		t.Fatalf("Expected TypeConversionError, but got %#v", err)
+	}
+}
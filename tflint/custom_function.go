@@ -0,0 +1,94 @@
+package tflint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FunctionSignature is the signature of a custom function provided by a plugin.
+// Functions cannot be sent via RPC, so hosts receive signatures and call functions remotely.
+type FunctionSignature struct {
+	Name   string
+	Params []FunctionParameter
+	// VarParam is the parameter of variadic arguments. It is nil if the function is not variadic.
+	VarParam *FunctionParameter
+}
+
+// FunctionParameter is a parameter of a custom function.
+type FunctionParameter struct {
+	Name         string
+	Type         cty.Type
+	AllowNull    bool
+	AllowUnknown bool
+}
+
+// CallFunctionRequest is the interface used to communicate via RPC.
+type CallFunctionRequest struct {
+	Name string
+	Args []cty.Value
+}
+
+// FunctionSignatures returns the signatures of the custom functions in the ruleset, sorted by name.
+func (r *RuleSet) FunctionSignatures() []*FunctionSignature {
+	signatures := []*FunctionSignature{}
+	for name, fn := range r.Functions {
+		signature := &FunctionSignature{Name: name, Params: []FunctionParameter{}}
+		for _, param := range fn.Params() {
+			signature.Params = append(signature.Params, newFunctionParameter(param))
+		}
+		if param := fn.VarParam(); param != nil {
+			varParam := newFunctionParameter(*param)
+			signature.VarParam = &varParam
+		}
+		signatures = append(signatures, signature)
+	}
+	sort.Slice(signatures, func(i, j int) bool { return signatures[i].Name < signatures[j].Name })
+	return signatures
+}
+
+func newFunctionParameter(param function.Parameter) FunctionParameter {
+	return FunctionParameter{Name: param.Name, Type: param.Type, AllowNull: param.AllowNull, AllowUnknown: param.AllowUnknown}
+}
+
+// CallFunction calls the custom function with the passed name in the ruleset.
+func (r *RuleSet) CallFunction(name string, args []cty.Value) (cty.Value, error) {
+	fn, exists := r.Functions[name]
+	if !exists {
+		return cty.NilVal, fmt.Errorf("Function not found: %s", name)
+	}
+	return fn.Call(args)
+}
+
+// FunctionName returns the name of the custom function exposed in the evaluation context of the host.
+// Functions are namespaced with the ruleset name (e.g. `acme_tags`) so that plugins cannot shadow
+// built-in functions or each other.
+func FunctionName(ruleset string, name string) string {
+	return fmt.Sprintf("%s_%s", ruleset, name)
+}
+
+// NewRemoteFunction returns a function with the passed signature that is implemented by the passed call.
+// The return type is not known until called, so it is dynamic.
+func NewRemoteFunction(signature *FunctionSignature, call func([]cty.Value) (cty.Value, error)) function.Function {
+	spec := &function.Spec{
+		Params: []function.Parameter{},
+		Type:   function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return call(args)
+		},
+	}
+	for _, param := range signature.Params {
+		spec.Params = append(spec.Params, param.spec())
+	}
+	if signature.VarParam != nil {
+		varParam := signature.VarParam.spec()
+		spec.VarParam = &varParam
+	}
+	return function.New(spec)
+}
+
+func (p FunctionParameter) spec() function.Parameter {
+	return function.Parameter{Name: p.Name, Type: p.Type, AllowNull: p.AllowNull, AllowUnknown: p.AllowUnknown}
+}
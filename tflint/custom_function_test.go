@@ -0,0 +1,72 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func Test_NewRemoteFunction(t *testing.T) {
+	ruleset := &RuleSet{Name: "acme", Functions: map[string]function.Function{
+		"name": function.New(&function.Spec{
+			Params:   []function.Parameter{{Name: "env", Type: cty.String}},
+			VarParam: &function.Parameter{Name: "parts", Type: cty.String},
+			Type:     function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+				name := args[0].AsString()
+				for _, part := range args[1:] {
+					name += "-" + part.AsString()
+				}
+				return cty.StringVal(name), nil
+			},
+		}),
+	}}
+
+	// Signatures are sent via RPC.
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ruleset.FunctionSignatures()); err != nil {
+		t.Fatal(err)
+	}
+	var signatures []*FunctionSignature
+	if err := gob.NewDecoder(&buf).Decode(&signatures); err != nil {
+		t.Fatal(err)
+	}
+
+	functions := map[string]function.Function{}
+	for _, signature := range signatures {
+		name := signature.Name
+		functions[FunctionName(ruleset.Name, name)] = NewRemoteFunction(signature, func(args []cty.Value) (cty.Value, error) {
+			return ruleset.CallFunction(name, args)
+		})
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(`acme_name("prod", "web", "1")`), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	val, diags := expr.Value(&hcl.EvalContext{Functions: functions})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if !val.RawEquals(cty.StringVal("prod-web-1")) {
+		t.Fatalf("Unexpected value: %#v", val)
+	}
+
+	// Parameter types are checked by the host.
+	expr, diags = hclsyntax.ParseExpression([]byte(`acme_name(["prod"])`), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if _, diags := expr.Value(&hcl.EvalContext{Functions: functions}); !diags.HasErrors() {
+		t.Fatal("Expected an error for an invalid argument")
+	}
+
+	if _, err := ruleset.CallFunction("unknown", nil); err == nil || err.Error() != "Function not found: unknown" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
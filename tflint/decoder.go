@@ -0,0 +1,99 @@
+package tflint
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DecodeFunc decodes the passed value into a value of the registered type.
+// It is only called with known and non-null values.
+type DecodeFunc func(cty.Value) (interface{}, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[reflect.Type]DecodeFunc{}
+)
+
+// RegisterDecoder registers the decoder for the type of the passed example value (e.g. ARN{}).
+// Once registered, EvaluateExpr decodes values into pointers to the type with the decoder, so plugins can
+// share conversions of their own types (e.g. ARN, CIDR, k8s quantity) across rules.
+// Registering the same type again replaces the decoder.
+//
+// Example:
+//
+//	tflint.RegisterDecoder(net.IPNet{}, func(val cty.Value) (interface{}, error) {
+//		_, cidr, err := net.ParseCIDR(val.AsString())
+//		if err != nil {
+//			return nil, err
+//		}
+//		return *cidr, nil
+//	})
+//
+//	var cidr net.IPNet
+//	err := runner.EvaluateExpr(attr.Expr, &cidr)
+func RegisterDecoder(example interface{}, decode DecodeFunc) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	decoders[reflect.TypeOf(example)] = decode
+}
+
+// DecodeValue decodes the value into ret with the decoder registered for the type ret points to.
+// It returns false if no decoder is registered. Errors are reported as TypeConversionError with the passed location.
+// This is mainly for Runner implementations.
+func DecodeValue(val cty.Value, ret interface{}, rng hcl.Range) (bool, error) {
+	decode, exists := decoderFor(ret)
+	if !exists {
+		return false, nil
+	}
+
+	if !val.IsWhollyKnown() {
+		return true, Error{
+			Code:    UnknownValueError,
+			Level:   WarningLevel,
+			Message: fmt.Sprintf("Unknown value found in %s:%d", rng.Filename, rng.Start.Line),
+		}
+	}
+	if val.IsNull() {
+		return true, Error{
+			Code:    NullValueError,
+			Level:   WarningLevel,
+			Message: fmt.Sprintf("Null value found in %s:%d", rng.Filename, rng.Start.Line),
+		}
+	}
+
+	target := reflect.ValueOf(ret).Elem()
+	decoded, err := decode(val)
+	if err == nil && reflect.TypeOf(decoded) != target.Type() {
+		err = fmt.Errorf("The decoder returned %T, but %s is expected", decoded, target.Type())
+	}
+	if err != nil {
+		return true, Error{
+			Code:    TypeConversionError,
+			Level:   ErrorLevel,
+			Message: fmt.Sprintf("Failed to decode the value in %s:%d", rng.Filename, rng.Start.Line),
+			Cause:   err,
+		}
+	}
+
+	target.Set(reflect.ValueOf(decoded))
+	return true, nil
+}
+
+// decoderFor returns the decoder registered for the type ret points to.
+func decoderFor(ret interface{}) (DecodeFunc, bool) {
+	ty := reflect.TypeOf(ret)
+	if ty == nil || ty.Kind() != reflect.Ptr {
+		return nil, false
+	}
+
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	decode, exists := decoders[ty.Elem()]
+	return decode, exists
+}
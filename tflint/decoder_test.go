@@ -0,0 +1,105 @@
+package tflint_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type testCIDR struct {
+	IP     string
+	Prefix int
+}
+
+func Test_RegisterDecoder(t *testing.T) {
+	tflint.RegisterDecoder(testCIDR{}, func(val cty.Value) (interface{}, error) {
+		parts := strings.Split(val.AsString(), "/")
+		if len(parts) != 2 {
+			return nil, errors.New("Invalid CIDR")
+		}
+		prefix, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return testCIDR{IP: parts[0], Prefix: prefix}, nil
+	})
+
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_vpc" "invalid" {
+  cidr_block = "10.0.0.0"
+}
+
+resource "aws_vpc" "null" {
+  cidr_block = null
+}`})
+
+	cases := []struct {
+		Name     string
+		Resource string
+		Expected testCIDR
+		Code     string
+	}{
+		{
+			Name:     "decoded",
+			Resource: "main",
+			Expected: testCIDR{IP: "10.0.0.0", Prefix: 16},
+		},
+		{
+			Name:     "decoder error",
+			Resource: "invalid",
+			Code:     tflint.TypeConversionError,
+		},
+		{
+			Name:     "null",
+			Resource: "null",
+			Code:     tflint.NullValueError,
+		},
+	}
+
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := map[string]*hcl.Attribute{}
+	for _, block := range content.Blocks {
+		attrs, diags := block.Body.JustAttributes()
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		attributes[block.Labels[1]] = attrs["cidr_block"]
+	}
+
+	for _, tc := range cases {
+		var got testCIDR
+		err := runner.EvaluateExpr(attributes[tc.Resource].Expr, &got)
+		if tc.Code != "" {
+			if appErr, ok := err.(tflint.Error); !ok || appErr.Code != tc.Code {
+				t.Fatalf("Failed `%s` test: expected %s, but got %#v", tc.Name, tc.Code, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, err)
+		}
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected %#v, but got %#v", tc.Name, tc.Expected, got)
+		}
+	}
+
+	var str string
+	if err := tflint.EvaluateExprWith(runner, attributes["main"].Expr, &str); err != nil || str != "10.0.0.0/16" {
+		t.Fatalf("Expected types without decoders not to be affected, but got `%s`, %v", str, err)
+	}
+}
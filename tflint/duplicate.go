@@ -0,0 +1,46 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DuplicateGroup is a set of attributes that have the same evaluated value.
+type DuplicateGroup struct {
+	Val cty.Value
+	// Sensitive reports whether any of the attributes is derived from sensitive values.
+	Sensitive bool
+	// Ranges is the set of ranges of the attributes in declaration order.
+	Ranges []hcl.Range
+}
+
+// NewDuplicateGroups groups the passed attributes by the evaluated value and returns only groups with two or more attributes.
+// Attributes whose values are not statically known or null are ignored. Groups are ordered by their first occurrence.
+func NewDuplicateGroups(attributes []*EvaluatedAttribute) []*DuplicateGroup {
+	groups := []*DuplicateGroup{}
+	index := map[string]*DuplicateGroup{}
+
+	for _, attribute := range attributes {
+		if attribute.Val == nil || !attribute.Val.IsWhollyKnown() || attribute.Val.IsNull() {
+			continue
+		}
+
+		key := attribute.Val.GoString()
+		group, exists := index[key]
+		if !exists {
+			group = &DuplicateGroup{Val: *attribute.Val, Ranges: []hcl.Range{}}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.Ranges = append(group.Ranges, attribute.Attribute.Expr.Range())
+		group.Sensitive = group.Sensitive || attribute.Sensitive
+	}
+
+	ret := []*DuplicateGroup{}
+	for _, group := range groups {
+		if len(group.Ranges) > 1 {
+			ret = append(ret, group)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,47 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_NewDuplicateGroups(t *testing.T) {
+	attribute := func(line int) *hcl.Attribute {
+		rng := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: line, Column: 1}, End: hcl.Pos{Line: line, Column: 10}}
+		return &hcl.Attribute{Name: "cidr_block", Expr: &hclsyntax.LiteralValueExpr{SrcRange: rng}, Range: rng}
+	}
+	val := func(v cty.Value) *cty.Value { return &v }
+
+	groups := NewDuplicateGroups([]*EvaluatedAttribute{
+		{Attribute: attribute(1), Val: val(cty.StringVal("10.0.0.0/16"))},
+		{Attribute: attribute(2), Val: val(cty.StringVal("10.1.0.0/16"))},
+		{Attribute: attribute(3), Val: val(cty.StringVal("10.0.0.0/16")), Sensitive: true},
+		{Attribute: attribute(4)},
+		{Attribute: attribute(5)},
+		{Attribute: attribute(6), Val: val(cty.NullVal(cty.String))},
+		{Attribute: attribute(7), Val: val(cty.NullVal(cty.String))},
+		{Attribute: attribute(8), Val: val(cty.StringVal("10.0.0.0/16"))},
+	})
+
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 group, but got %d", len(groups))
+	}
+	group := groups[0]
+	if !group.Val.RawEquals(cty.StringVal("10.0.0.0/16")) {
+		t.Fatalf("Unexpected value: %#v", group.Val)
+	}
+	if !group.Sensitive {
+		t.Fatal("Expected the group to be sensitive")
+	}
+	lines := []int{}
+	for _, rng := range group.Ranges {
+		lines = append(lines, rng.Start.Line)
+	}
+	if !cmp.Equal([]int{1, 3, 8}, lines) {
+		t.Fatalf("Unexpected ranges: %s", cmp.Diff([]int{1, 3, 8}, lines))
+	}
+}
@@ -0,0 +1,60 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/dynblock"
+)
+
+// ExpandedBlock is a nested block of a resource after `dynamic` blocks are expanded.
+// A `dynamic` block is expanded into blocks for each element of the `for_each`, and static blocks are included as is,
+// so rules see the logical blocks users intended.
+type ExpandedBlock struct {
+	Type string
+	// DefRange is the range of the block header. For blocks generated from a `dynamic` block, it is the header of the `dynamic` block.
+	DefRange hcl.Range
+	// Attributes is the set of attributes keyed by name with values evaluated by the host.
+	// Expressions are the original ones (in the `content` block for dynamic blocks), and values are evaluated with the iterator bound.
+	// If the `for_each` is not known, a single block with unknown values is generated.
+	Attributes map[string]*EvaluatedAttribute
+}
+
+// NewExpandedBlocks expands `dynamic` blocks in the passed resource block with the passed context,
+// and returns nested blocks of the passed type in declaration order.
+// Nested blocks are assumed to have no labels, which is true for nested blocks of resources except provisioners.
+// Sensitive is not set, as it depends on the host.
+func NewExpandedBlocks(resource *hcl.Block, blockType string, ctx *hcl.EvalContext) ([]*ExpandedBlock, hcl.Diagnostics) {
+	body := dynblock.Expand(resource.Body, ctx)
+	content, _, diags := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: blockType}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	blocks := []*ExpandedBlock{}
+	for _, block := range content.Blocks {
+		// JustAttributes passes through the original attributes. Nested blocks are reported as errors,
+		// but attributes are still returned, so they are only used to build the schema.
+		raw, _ := block.Body.JustAttributes()
+		schema := &hcl.BodySchema{}
+		for name := range raw {
+			schema.Attributes = append(schema.Attributes, hcl.AttributeSchema{Name: name})
+		}
+		// Attributes in the content are wrapped so that they can refer to the iterator.
+		expanded, _, diags := block.Body.PartialContent(schema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		attributes := map[string]*EvaluatedAttribute{}
+		for name, attribute := range raw {
+			evaluated := &EvaluatedAttribute{Attribute: attribute}
+			if val, diags := expanded.Attributes[name].Expr.Value(ctx); !diags.HasErrors() && val.IsWhollyKnown() {
+				evaluated.Val = &val
+			}
+			attributes[name] = evaluated
+		}
+		blocks = append(blocks, &ExpandedBlock{Type: block.Type, DefRange: block.DefRange, Attributes: attributes})
+	}
+	return blocks, nil
+}
@@ -0,0 +1,76 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_NewExpandedBlocks(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Vars     map[string]cty.Value
+		Expected []string
+	}{
+		{
+			Name: "known for_each",
+			Vars: map[string]cty.Value{"sizes": cty.ListVal([]cty.Value{cty.NumberIntVal(20), cty.NumberIntVal(30)})},
+			Expected: []string{
+				"main.tf:3,3-19", "cty.NumberIntVal(10)", "main.tf:4,19-21",
+				"main.tf:7,3-29", "cty.NumberIntVal(20)", "main.tf:11,21-43",
+				"main.tf:7,3-29", "cty.NumberIntVal(30)", "main.tf:11,21-43",
+			},
+		},
+		{
+			Name: "unknown for_each",
+			Vars: map[string]cty.Value{"sizes": cty.UnknownVal(cty.List(cty.Number))},
+			Expected: []string{
+				"main.tf:3,3-19", "cty.NumberIntVal(10)", "main.tf:4,19-21",
+				"main.tf:7,3-29", "unknown", "main.tf:11,21-43",
+			},
+		},
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(`
+resource "aws_instance" "web" {
+  ebs_block_device {
+    volume_size = 10
+  }
+
+  dynamic "ebs_block_device" {
+    for_each = var.sizes
+
+    content {
+      volume_size = ebs_block_device.value
+    }
+  }
+}`), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("Unexpected error occurred: %s", diags)
+	}
+	resource := file.Body.(*hclsyntax.Body).Blocks[0].AsHCLBlock()
+
+	for _, tc := range cases {
+		ctx := &hcl.EvalContext{Variables: map[string]cty.Value{"var": cty.ObjectVal(tc.Vars)}}
+		blocks, diags := NewExpandedBlocks(resource, "ebs_block_device", ctx)
+		if diags.HasErrors() {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, diags)
+		}
+
+		got := []string{}
+		for _, block := range blocks {
+			attribute := block.Attributes["volume_size"]
+			val := "unknown"
+			if attribute.Val != nil {
+				val = attribute.Val.GoString()
+			}
+			got = append(got, block.DefRange.String(), val, attribute.Attribute.Expr.Range().String())
+		}
+		if !cmp.Equal(tc.Expected, got) {
+			t.Fatalf("Failed `%s` test: diff: %s", tc.Name, cmp.Diff(tc.Expected, got))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package tflint
+
+import (
+	"bytes"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The following utilities build minimal text edits for fixes.
+// Unlike round-tripping a file through hclwrite, only the necessary bytes are replaced,
+// so comments, indentation and the rest of the formatting are preserved and fix diffs stay reviewable.
+
+// ReplaceExpr returns an edit that replaces only the passed expression with the text.
+func ReplaceExpr(src []byte, expr hcl.Expression, text string) TextEdit {
+	return MinimizeEdit(src, TextEdit{Range: expr.Range(), NewText: text})
+}
+
+// SetValue returns an edit that replaces the passed expression with the literal of the value.
+func SetValue(src []byte, expr hcl.Expression, val cty.Value) TextEdit {
+	return ReplaceExpr(src, expr, string(hclwrite.TokensForValue(val).Bytes()))
+}
+
+// RemoveAttribute returns an edit that removes the line(s) of the passed attribute.
+// If the line has other content (e.g. a trailing comment), only the attribute itself is removed.
+func RemoveAttribute(src []byte, attr *hcl.Attribute) TextEdit {
+	start := attr.Range.Start.Byte
+	end := attr.Range.End.Byte
+
+	lineStart := bytes.LastIndexByte(src[:start], '\n') + 1
+	lineEnd := len(src)
+	if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+		lineEnd = end + i + 1
+	}
+
+	if len(bytes.TrimSpace(src[lineStart:start])) == 0 && len(bytes.TrimSpace(src[end:lineEnd])) == 0 {
+		start, end = lineStart, lineEnd
+	}
+	return TextEdit{Range: rangeOf(src, attr.Range.Filename, start, end), NewText: ""}
+}
+
+// InsertAttribute returns an edit that inserts a new attribute at the end of the passed block.
+// The indentation is inferred from the block header. Only blocks in native syntax are supported.
+func InsertAttribute(src []byte, block *hcl.Block, name string, val cty.Value) (TextEdit, bool) {
+	body, ok := block.Body.(*hclsyntax.Body)
+	if !ok {
+		return TextEdit{}, false
+	}
+
+	indent := strings.Repeat(" ", block.DefRange.Start.Column-1)
+	// The range of the body includes the braces
+	closing := body.SrcRange.End.Byte - 1
+	lineStart := bytes.LastIndexByte(src[:closing], '\n') + 1
+
+	var text strings.Builder
+	pos := lineStart
+	if len(bytes.TrimSpace(src[lineStart:closing])) > 0 {
+		// The closing brace is on the same line as other content (e.g. `resource "a" "b" {}`)
+		pos = closing
+		text.WriteString("\n")
+	}
+	text.WriteString(indent + "  " + name + " = " + string(hclwrite.TokensForValue(val).Bytes()) + "\n")
+	if pos == closing {
+		text.WriteString(indent)
+	}
+
+	return TextEdit{Range: rangeOf(src, block.DefRange.Filename, pos, pos), NewText: text.String()}, true
+}
+
+// MinimizeEdit shrinks the passed edit by excluding the common prefix and suffix of the old and new text.
+func MinimizeEdit(src []byte, edit TextEdit) TextEdit {
+	start := edit.Range.Start.Byte
+	end := edit.Range.End.Byte
+	old := src[start:end]
+	text := edit.NewText
+
+	prefix := 0
+	for prefix < len(old) && prefix < len(text) && old[prefix] == text[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(text)-prefix && old[len(old)-1-suffix] == text[len(text)-1-suffix] {
+		suffix++
+	}
+
+	return TextEdit{
+		Range:   rangeOf(src, edit.Range.Filename, start+prefix, end-suffix),
+		NewText: text[prefix : len(text)-suffix],
+	}
+}
+
+// EditsBetween returns a minimal edit that turns the source before into the source after.
+// This is useful to convert the result of hclwrite into an edit without rewriting the whole file.
+// No edits are returned if there is no difference.
+func EditsBetween(filename string, before, after []byte) []TextEdit {
+	if bytes.Equal(before, after) {
+		return []TextEdit{}
+	}
+	edit := MinimizeEdit(before, TextEdit{Range: rangeOf(before, filename, 0, len(before)), NewText: string(after)})
+	return []TextEdit{edit}
+}
+
+// rangeOf returns the range between the passed byte offsets with line and column numbers
+func rangeOf(src []byte, filename string, start, end int) hcl.Range {
+	return hcl.Range{Filename: filename, Start: posOf(src, start), End: posOf(src, end)}
+}
+
+func posOf(src []byte, offset int) hcl.Pos {
+	line := bytes.Count(src[:offset], []byte("\n")) + 1
+	column := offset - (bytes.LastIndexByte(src[:offset], '\n') + 1) + 1
+	return hcl.Pos{Line: line, Column: column, Byte: offset}
+}
@@ -0,0 +1,115 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_edits(t *testing.T) {
+	src := []byte(`resource "aws_instance" "web" {
+  # The instance type
+  instance_type = "t1.2xlarge" # legacy
+  ami           = "ami-1234"
+}
+`)
+
+	file, diags := hclsyntax.ParseConfig(src, "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	block := content.Blocks[0]
+	attributes, diags := block.Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	insert, ok := InsertAttribute(src, block, "monitoring", cty.True)
+	if !ok {
+		t.Fatal("Expected the attribute to be inserted")
+	}
+
+	cases := []struct {
+		Name     string
+		Edit     TextEdit
+		Expected string
+	}{
+		{
+			Name: "set value",
+			Edit: SetValue(src, attributes["instance_type"].Expr, cty.StringVal("t2.micro")),
+			Expected: `resource "aws_instance" "web" {
+  # The instance type
+  instance_type = "t2.micro" # legacy
+  ami           = "ami-1234"
+}
+`,
+		},
+		{
+			Name: "remove attribute with a trailing comment",
+			Edit: RemoveAttribute(src, attributes["instance_type"]),
+			Expected: `resource "aws_instance" "web" {
+  # The instance type
+   # legacy
+  ami           = "ami-1234"
+}
+`,
+		},
+		{
+			Name: "remove attribute",
+			Edit: RemoveAttribute(src, attributes["ami"]),
+			Expected: `resource "aws_instance" "web" {
+  # The instance type
+  instance_type = "t1.2xlarge" # legacy
+}
+`,
+		},
+		{
+			Name: "insert attribute",
+			Edit: insert,
+			Expected: `resource "aws_instance" "web" {
+  # The instance type
+  instance_type = "t1.2xlarge" # legacy
+  ami           = "ami-1234"
+  monitoring = true
+}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		fixed, _, err := ApplyFixes(map[string][]byte{"main.tf": src}, []*Fix{{Edits: []TextEdit{tc.Edit}}})
+		if err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+		if string(fixed["main.tf"]) != tc.Expected {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, cmp.Diff(tc.Expected, string(fixed["main.tf"])))
+		}
+	}
+
+	// SetValue only replaces the differing bytes
+	edit := SetValue(src, attributes["ami"].Expr, cty.StringVal("ami-5678"))
+	if edit.NewText != "5678" || edit.Range.Start.Line != 4 {
+		t.Fatalf("Expected a minimal edit, but got %#v", edit)
+	}
+
+	// A round trip through hclwrite is converted into a minimal edit
+	wfile, diags := hclwrite.ParseConfig(src, "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	wfile.Body().Blocks()[0].Body().SetAttributeValue("ami", cty.StringVal("ami-5678"))
+	edits := EditsBetween("main.tf", src, wfile.Bytes())
+	if len(edits) != 1 || edits[0].NewText != "5678" {
+		t.Fatalf("Expected a minimal edit, but got %#v", edits)
+	}
+}
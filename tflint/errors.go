@@ -1,6 +1,12 @@
 package tflint
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/rpc"
+	"strings"
+	"time"
+)
 
 const (
 	// EvaluationError is an error when interpolation failed (unexpected)
@@ -51,3 +57,103 @@ func (e Error) Error() string {
 
 	return e.Message
 }
+
+// IsUnknownValueError reports whether the passed error, or any error it wraps, is UnknownValueError.
+// Rules can use it to skip expressions that refer to unknown values (e.g. computed attributes) explicitly.
+func IsUnknownValueError(err error) bool {
+	var appErr Error
+	return errors.As(err, &appErr) && appErr.Code == UnknownValueError
+}
+
+// RuleError is an error that occurred while checking a rule.
+// It holds the message instead of the original error so that it can be sent via RPC.
+type RuleError struct {
+	Rule    string
+	Message string
+}
+
+// Error shows error message with the rule name.
+func (e RuleError) Error() string {
+	return fmt.Sprintf("Failed to check `%s` rule: %s", e.Rule, e.Message)
+}
+
+// RuleErrors is a list of errors aggregated from multiple rules.
+// This allows the host to report all failing rules instead of only the first.
+type RuleErrors []RuleError
+
+// Error shows all error messages separated by newlines.
+func (e RuleErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// CallBudgetError is an error when a rule makes more RPC calls than the budget.
+// It is usually caused by querying the host in loops, which can be replaced with bulk APIs.
+type CallBudgetError struct {
+	Rule   string
+	Budget int
+	// Method is the name of the RPC method that exceeded the budget.
+	Method string
+}
+
+// Error shows error message with a hint about bulk APIs.
+func (e CallBudgetError) Error() string {
+	return fmt.Sprintf(
+		"`%s` rule exceeded the budget of %d RPC calls at %s. Consider using bulk APIs such as GetModuleContent or WalkResourceAttributeValues instead of querying in loops",
+		e.Rule,
+		e.Budget,
+		e.Method,
+	)
+}
+
+// ProtocolError is an error when an RPC call to the host fails (e.g. the host cannot decode the request).
+// It is usually caused by a version mismatch between the host and the SDK that the plugin is built with.
+type ProtocolError struct {
+	// Method is the name of the RPC method (e.g. "Plugin.EvalExpr").
+	Method string
+	// Request is a short summary of the request.
+	Request string
+	Cause   error
+}
+
+// Error shows error message with the method name and the request summary.
+// A hint about version mismatch is added only if the request or the response cannot be encoded or decoded.
+func (e ProtocolError) Error() string {
+	msg := fmt.Sprintf("Failed to call %s(%s): %s", e.Method, e.Request, e.Cause)
+	if incompatible(e.Cause) {
+		msg += ". This may be caused by an incompatible version of TFLint and the plugin SDK"
+	}
+	return msg
+}
+
+// Unwrap returns the cause, so errors such as rpc.ErrShutdown and TimeoutError can be detected with errors.Is and errors.As.
+func (e ProtocolError) Unwrap() error {
+	return e.Cause
+}
+
+// incompatible returns true if the error is a gob decode or type error.
+// Errors returned by the host (rpc.ServerError) are not, as the request reached the host and was handled there.
+func incompatible(err error) bool {
+	var serverErr rpc.ServerError
+	if err == nil || errors.As(err, &serverErr) {
+		return false
+	}
+	var timeoutErr TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return false
+	}
+	return strings.Contains(err.Error(), "gob: ")
+}
+
+// TimeoutError is an error when the host does not respond within Options.Timeout.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+// Error shows error message with the timeout.
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s", e.Timeout)
+}
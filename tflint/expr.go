@@ -0,0 +1,185 @@
+package tflint
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// WireExprVersion is the version of the wire representation of expressions.
+// It will be updated by incompatible changes of the representation.
+const WireExprVersion = 1
+
+const (
+	// NativeSyntax is the native syntax of HCL (*.tf)
+	NativeSyntax = "hcl"
+	// JSONSyntax is the JSON syntax of HCL (*.tf.json)
+	JSONSyntax = "json"
+)
+
+// UTF8Encoding is the encoding of the source of expressions. Only UTF-8 is supported for now.
+const UTF8Encoding = "utf-8"
+
+// WireExpr is a wire representation of an expression.
+//
+// Some expressions (e.g. in the hcl/json package) cannot be sent via RPC, and the meaning of a JSON value depends on
+// the surrounding context (e.g. a string is interpreted as a template only in attribute context).
+// So the host sends the source together with its syntax, encoding and the traversals computed in the original context,
+// and rules see the same semantics as expressions in native syntax.
+//
+// The syntax is stated explicitly instead of being inferred from the filename suffix, and the representation is versioned,
+// so future syntaxes and sources preprocessed by the host are rejected with diagnostics instead of being silently misread.
+type WireExpr struct {
+	Version    int
+	Syntax     string
+	Encoding   string
+	Src        []byte
+	SrcRange   hcl.Range
+	Traversals []hcl.Traversal
+}
+
+var _ hcl.Expression = (*WireExpr)(nil)
+
+// NewWireExpr returns a wire representation of the passed expression.
+// The second argument is the content of the file in which the expression is declared.
+// The syntax is determined by the type of the expression.
+func NewWireExpr(expr hcl.Expression, file []byte) *WireExpr {
+	rng := expr.Range()
+
+	syntax := JSONSyntax
+	if _, ok := expr.(hclsyntax.Expression); ok {
+		syntax = NativeSyntax
+	}
+
+	return &WireExpr{
+		Version:    WireExprVersion,
+		Syntax:     syntax,
+		Encoding:   UTF8Encoding,
+		Src:        rng.SliceBytes(file),
+		SrcRange:   rng,
+		Traversals: expr.Variables(),
+	}
+}
+
+// Value parses the source according to the syntax and returns the value.
+// The subject of diagnostics is the range of the original expression.
+func (e *WireExpr) Value(ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	if diags := e.validate(); diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	switch e.Syntax {
+	case NativeSyntax:
+		expr, diags := e.native()
+		if diags.HasErrors() {
+			return cty.DynamicVal, diags
+		}
+		return expr.Value(ctx)
+	default:
+		return e.jsonValue(ctx)
+	}
+}
+
+// native parses the source as an expression in native syntax.
+func (e *WireExpr) native() (hclsyntax.Expression, hcl.Diagnostics) {
+	return hclsyntax.ParseExpression(e.Src, e.SrcRange.Filename, e.SrcRange.Start)
+}
+
+// Variables returns the traversals computed in the original context
+func (e *WireExpr) Variables() []hcl.Traversal {
+	return e.Traversals
+}
+
+// Range returns the range of the original expression
+func (e *WireExpr) Range() hcl.Range {
+	return e.SrcRange
+}
+
+// StartRange returns the range of the original expression
+func (e *WireExpr) StartRange() hcl.Range {
+	return e.SrcRange
+}
+
+func (e *WireExpr) validate() hcl.Diagnostics {
+	var detail string
+
+	switch {
+	case e.Version != WireExprVersion:
+		detail = fmt.Sprintf("The expression is encoded in wire version %d, but this plugin supports version %d. This may be caused by an incompatible version of TFLint and the plugin SDK", e.Version, WireExprVersion)
+	case e.Syntax != NativeSyntax && e.Syntax != JSONSyntax:
+		detail = fmt.Sprintf("The expression is written in unsupported syntax `%s`", e.Syntax)
+	case e.Encoding != UTF8Encoding:
+		detail = fmt.Sprintf("The expression is encoded in unsupported encoding `%s`", e.Encoding)
+	case !utf8.Valid(e.Src):
+		detail = "The expression source is not valid UTF-8"
+	default:
+		return nil
+	}
+
+	rng := e.SrcRange
+	return hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported expression",
+			Detail:   detail,
+			Subject:  &rng,
+		},
+	}
+}
+
+func (e *WireExpr) jsonValue(ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	// Wrap the source as an attribute so that it is interpreted in the same context as the original.
+	var src bytes.Buffer
+	src.WriteString(`{"value":`)
+	src.Write(e.Src)
+	src.WriteString(`}`)
+
+	file, diags := json.Parse(src.Bytes(), e.SrcRange.Filename)
+	if diags.HasErrors() {
+		return cty.DynamicVal, e.relocate(diags)
+	}
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return cty.DynamicVal, e.relocate(diags)
+	}
+
+	val, diags := attributes["value"].Expr.Value(ctx)
+	return val, e.relocate(diags)
+}
+
+func (e *WireExpr) relocate(diags hcl.Diagnostics) hcl.Diagnostics {
+	for _, diag := range diags {
+		rng := e.SrcRange
+		diag.Subject = &rng
+		diag.Context = nil
+	}
+	return diags
+}
+
+// sendableExpr returns false if the expression in native syntax cannot be encoded by gob.
+// Operators hold functions, and literals of dynamic types (e.g. `null`) have no concrete type.
+func sendableExpr(expr hcl.Expression) bool {
+	native, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return true
+	}
+
+	ret := true
+	hclsyntax.VisitAll(native, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch node := node.(type) {
+		case *hclsyntax.BinaryOpExpr, *hclsyntax.UnaryOpExpr:
+			ret = false
+		case *hclsyntax.LiteralValueExpr:
+			if node.Val.Type().HasDynamicTypes() {
+				ret = false
+			}
+		}
+		return nil
+	})
+	return ret
+}
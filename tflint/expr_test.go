@@ -0,0 +1,167 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_WireExpr_JSON(t *testing.T) {
+	src := []byte(`{
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "instance_type": "${var.type}.micro",
+        "tags": { "Name": "web" }
+      }
+    }
+  }
+}`)
+
+	file, diags := json.Parse(src, "main.tf.json")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	attributes, diags := content.Blocks[0].Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(map[string]cty.Value{"type": cty.StringVal("t2")})},
+	}
+
+	cases := []struct {
+		Name      string
+		Attribute string
+		Expected  cty.Value
+		Variables int
+	}{
+		{
+			Name:      "template",
+			Attribute: "instance_type",
+			Expected:  cty.StringVal("t2.micro"),
+			Variables: 1,
+		},
+		{
+			Name:      "object",
+			Attribute: "tags",
+			Expected:  cty.ObjectVal(map[string]cty.Value{"Name": cty.StringVal("web")}),
+			Variables: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		original := attributes[tc.Attribute].Expr
+		expr := NewWireExpr(original, src)
+		if expr.Syntax != JSONSyntax {
+			t.Fatalf("Failed `%s` test: expected JSON syntax, but got `%s`", tc.Name, expr.Syntax)
+		}
+
+		if !cmp.Equal(expr.Range(), original.Range()) {
+			t.Fatalf("Failed `%s` test: range=%#v, expected=%#v", tc.Name, expr.Range(), original.Range())
+		}
+		if len(expr.Variables()) != tc.Variables {
+			t.Fatalf("Failed `%s` test: expected %d variables, but got %#v", tc.Name, tc.Variables, expr.Variables())
+		}
+
+		val, diags := expr.Value(ctx)
+		if diags.HasErrors() {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, diags)
+		}
+		if !val.RawEquals(tc.Expected) {
+			t.Fatalf("Failed `%s` test: expected %#v, but got %#v", tc.Name, tc.Expected, val)
+		}
+	}
+}
+
+func Test_WireExpr_Native(t *testing.T) {
+	src := []byte(`instance_type = "${var.type}.micro"`)
+	file, diags := hclsyntax.ParseConfig(src, "main.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	original := attributes["instance_type"].Expr
+	expr := NewWireExpr(original, src)
+	if expr.Syntax != NativeSyntax {
+		t.Fatalf("Expected native syntax, but got `%s`", expr.Syntax)
+	}
+	if !cmp.Equal(expr.Range(), original.Range()) {
+		t.Fatalf("range=%#v, expected=%#v", expr.Range(), original.Range())
+	}
+
+	val, diags := expr.Value(&hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(map[string]cty.Value{"type": cty.StringVal("t2")})},
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if !val.RawEquals(cty.StringVal("t2.micro")) {
+		t.Fatalf("Expected t2.micro, but got %#v", val)
+	}
+}
+
+func Test_WireExpr_Unsupported(t *testing.T) {
+	valid := WireExpr{
+		Version:  WireExprVersion,
+		Syntax:   NativeSyntax,
+		Encoding: UTF8Encoding,
+		Src:      []byte(`"t2.micro"`),
+		SrcRange: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}},
+	}
+
+	cases := []struct {
+		Name     string
+		Modify   func(*WireExpr)
+		Expected string
+	}{
+		{
+			Name:     "future version",
+			Modify:   func(e *WireExpr) { e.Version = WireExprVersion + 1 },
+			Expected: "The expression is encoded in wire version 2, but this plugin supports version 1. This may be caused by an incompatible version of TFLint and the plugin SDK",
+		},
+		{
+			Name:     "unknown syntax",
+			Modify:   func(e *WireExpr) { e.Syntax = "yaml" },
+			Expected: "The expression is written in unsupported syntax `yaml`",
+		},
+		{
+			Name:     "unknown encoding",
+			Modify:   func(e *WireExpr) { e.Encoding = "utf-16" },
+			Expected: "The expression is encoded in unsupported encoding `utf-16`",
+		},
+		{
+			Name:     "invalid source",
+			Modify:   func(e *WireExpr) { e.Src = []byte{0xff} },
+			Expected: "The expression source is not valid UTF-8",
+		},
+	}
+
+	for _, tc := range cases {
+		expr := valid
+		tc.Modify(&expr)
+
+		_, diags := expr.Value(nil)
+		if !diags.HasErrors() {
+			t.Fatalf("Failed `%s` test: expected an error, but got nothing", tc.Name)
+		}
+		if diags[0].Detail != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected `%s`, but got `%s`", tc.Name, tc.Expected, diags[0].Detail)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tflint
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// filenameRefPrefix is the prefix of references to FilenameTable. Filenames never start with NUL.
+const filenameRefPrefix = "\x00"
+
+var rangeType = reflect.TypeOf(hcl.Range{})
+
+// FilenameTable is a table of filenames used to compact responses.
+// Responses for large modules repeat long filenames in every range (attributes, expressions, traversals...),
+// so hosts replace filenames with short references to the table, and the table is sent once per response.
+//
+// Only ranges reachable through pointers, interfaces, slices and exported fields are replaced (e.g. ranges in non-pointer map values
+// are not). Other ranges are left as is, so decoding is always safe even if some ranges are not encoded.
+type FilenameTable []string
+
+// Encode replaces filenames of ranges in the passed value with references to the table, adding new filenames to the table.
+// The value must be a pointer.
+func (t *FilenameTable) Encode(v interface{}) {
+	index := map[string]int{}
+	for i, filename := range *t {
+		index[filename] = i
+	}
+
+	walkRanges(reflect.ValueOf(v), map[uintptr]bool{}, func(filename string) string {
+		if filename == "" || strings.HasPrefix(filename, filenameRefPrefix) {
+			return filename
+		}
+		i, exists := index[filename]
+		if !exists {
+			i = len(*t)
+			index[filename] = i
+			*t = append(*t, filename)
+		}
+		return filenameRefPrefix + strconv.Itoa(i)
+	})
+}
+
+// Decode restores filenames of ranges in the passed value from references to the table.
+// Filenames that are not references are left as is, so decoding values more than once is harmless.
+func (t FilenameTable) Decode(v interface{}) {
+	if len(t) == 0 {
+		return
+	}
+
+	walkRanges(reflect.ValueOf(v), map[uintptr]bool{}, func(filename string) string {
+		if !strings.HasPrefix(filename, filenameRefPrefix) {
+			return filename
+		}
+		i, err := strconv.Atoi(strings.TrimPrefix(filename, filenameRefPrefix))
+		if err != nil || i < 0 || i >= len(t) {
+			return filename
+		}
+		return t[i]
+	})
+}
+
+// walkRanges calls the passed function for the filename of each settable range in the value, and sets the result.
+func walkRanges(v reflect.Value, visited map[uintptr]bool, f func(string) string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		walkRanges(v.Elem(), visited, f)
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr {
+			walkRanges(elem, visited, f)
+			return
+		}
+		// Other values in interfaces (e.g. traversers) are not addressable, so replace them with modified copies.
+		if v.CanSet() {
+			copied := reflect.New(elem.Type()).Elem()
+			copied.Set(elem)
+			walkRanges(copied, visited, f)
+			v.Set(copied)
+		}
+	case reflect.Struct:
+		if v.Type() == rangeType {
+			if filename := v.FieldByName("Filename"); filename.CanSet() {
+				filename.SetString(f(filename.String()))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			walkRanges(v.Field(i), visited, f)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkRanges(v.Index(i), visited, f)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			walkRanges(v.MapIndex(key), visited, f)
+		}
+	}
+}
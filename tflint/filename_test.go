@@ -0,0 +1,73 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_FilenameTable(t *testing.T) {
+	RegisterWireTypes()
+
+	parse := func() []*hcl.Attribute {
+		var src strings.Builder
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(&src, "instance_type_%d = \"${var.prefix}-%d\"\n", i, i)
+		}
+		file, diags := hclsyntax.ParseConfig([]byte(src.String()), "/home/user/workspace/terraform/modules/compute/instances/main.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("Unexpected error occurred: %s", diags)
+		}
+		attributes := []*hcl.Attribute{}
+		for _, attribute := range file.Body.(*hclsyntax.Body).Attributes {
+			attributes = append(attributes, attribute.AsHCLAttribute())
+		}
+		sort.Slice(attributes, func(i, j int) bool { return attributes[i].Name < attributes[j].Name })
+		return attributes
+	}
+	ranges := func(attributes []*hcl.Attribute) []string {
+		ret := []string{}
+		for _, attribute := range attributes {
+			ret = append(ret, attribute.Range.String(), attribute.NameRange.String(), attribute.Expr.Range().String())
+			for _, traversal := range attribute.Expr.Variables() {
+				ret = append(ret, traversal.SourceRange().String())
+			}
+		}
+		return ret
+	}
+	size := func(resp *AttributesResponse) int {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		return buf.Len()
+	}
+
+	plain := &AttributesResponse{Attributes: parse()}
+	encoded := &AttributesResponse{Attributes: parse()}
+	encoded.Filenames.Encode(&encoded.Attributes)
+
+	if len(encoded.Filenames) != 1 {
+		t.Fatalf("Expected 1 filename in the table, but got %#v", encoded.Filenames)
+	}
+	if size(encoded) >= size(plain)*7/10 {
+		t.Fatalf("Expected the encoded response to be 30%% smaller, but got %d bytes (plain: %d bytes)", size(encoded), size(plain))
+	}
+
+	encoded.Filenames.Decode(&encoded.Attributes)
+	if !cmp.Equal(ranges(plain.Attributes), ranges(encoded.Attributes)) {
+		t.Fatalf("Diff: %s", cmp.Diff(ranges(plain.Attributes), ranges(encoded.Attributes)))
+	}
+	// Decoding twice is harmless
+	encoded.Filenames.Decode(&encoded.Attributes)
+	if !cmp.Equal(ranges(plain.Attributes), ranges(encoded.Attributes)) {
+		t.Fatalf("Diff: %s", cmp.Diff(ranges(plain.Attributes), ranges(encoded.Attributes)))
+	}
+}
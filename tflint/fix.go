@@ -0,0 +1,173 @@
+package tflint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// TextEdit is a replacement of the text in the range with NewText.
+// An empty range (Start == End) inserts the text.
+type TextEdit struct {
+	Range   hcl.Range
+	NewText string
+}
+
+// Fix is a set of text edits that resolves an issue.
+// Rules attach it to Metadata when emitting the issue, and the host applies it with `--fix`.
+type Fix struct {
+	// Rule is the name of the rule proposing the fix. It is set by the SDK when emitting.
+	Rule  string
+	Edits []TextEdit
+}
+
+// FixConflict is a fix that was not applied because it overlaps with a fix applied earlier.
+type FixConflict struct {
+	Fix *Fix
+	// With is the applied fix that the fix overlaps with.
+	With *Fix
+}
+
+// Error shows the conflicting rules.
+func (c FixConflict) Error() string {
+	return fmt.Sprintf("The fix of `%s` rule conflicts with the fix of `%s` rule", c.Fix.Rule, c.With.Rule)
+}
+
+// ApplyFixes applies the passed fixes to the sources keyed by filenames.
+// Fixes are applied in order, and a fix that overlaps with a fix applied earlier is skipped as a whole
+// and returned as a conflict, so that the result never contains a half-applied fix.
+// The passed sources are not modified.
+func ApplyFixes(sources map[string][]byte, fixes []*Fix) (map[string][]byte, []FixConflict, error) {
+	applied := []*Fix{}
+	conflicts := []FixConflict{}
+
+	for _, fix := range fixes {
+		if with := findConflict(fix, applied); with != nil {
+			conflicts = append(conflicts, FixConflict{Fix: fix, With: with})
+			continue
+		}
+		applied = append(applied, fix)
+	}
+
+	edits := map[string][]TextEdit{}
+	for _, fix := range applied {
+		for _, edit := range fix.Edits {
+			if _, exists := sources[edit.Range.Filename]; !exists {
+				return nil, nil, fmt.Errorf("The fix of `%s` rule edits an unknown file: %s", fix.Rule, edit.Range.Filename)
+			}
+			edits[edit.Range.Filename] = append(edits[edit.Range.Filename], edit)
+		}
+	}
+
+	ret := map[string][]byte{}
+	for filename, src := range sources {
+		fixed, err := applyEdits(src, edits[filename])
+		if err != nil {
+			return nil, nil, err
+		}
+		ret[filename] = fixed
+	}
+	return ret, conflicts, nil
+}
+
+// PreviewFixes returns the diff that would be made by applying the passed fixes, without changing anything.
+func PreviewFixes(sources map[string][]byte, fixes []*Fix) (string, []FixConflict, error) {
+	fixed, conflicts, err := ApplyFixes(sources, fixes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filenames := make([]string, 0, len(sources))
+	for filename := range sources {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var diff strings.Builder
+	for _, filename := range filenames {
+		diff.WriteString(Diff(filename, sources[filename], fixed[filename]))
+	}
+	return diff.String(), conflicts, nil
+}
+
+// Diff returns a line-based diff between the passed sources in the unified format.
+// Unchanged lines at the beginning and the end are omitted, so each file has at most one hunk.
+// An empty string is returned if there is no difference.
+func Diff(filename string, before, after []byte) string {
+	if bytes.Equal(before, after) {
+		return ""
+	}
+
+	oldLines := strings.SplitAfter(string(before), "\n")
+	newLines := strings.SplitAfter(string(after), "\n")
+
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix && oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	removed := oldLines[prefix : len(oldLines)-suffix]
+	added := newLines[prefix : len(newLines)-suffix]
+
+	var diff strings.Builder
+	fmt.Fprintf(&diff, "--- %s\n+++ %s\n", filename, filename)
+	fmt.Fprintf(&diff, "@@ -%d,%d +%d,%d @@\n", prefix+1, len(removed), prefix+1, len(added))
+	for _, line := range removed {
+		diff.WriteString("-" + strings.TrimSuffix(line, "\n") + "\n")
+	}
+	for _, line := range added {
+		diff.WriteString("+" + strings.TrimSuffix(line, "\n") + "\n")
+	}
+	return diff.String()
+}
+
+func findConflict(fix *Fix, applied []*Fix) *Fix {
+	for _, other := range applied {
+		for _, edit := range fix.Edits {
+			for _, otherEdit := range other.Edits {
+				if overlaps(edit.Range, otherEdit.Range) {
+					return other
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// overlaps reports whether the passed ranges overlap. Insertions at the same position also conflict
+// because the order of the inserted texts is ambiguous.
+func overlaps(a, b hcl.Range) bool {
+	if a.Filename != b.Filename {
+		return false
+	}
+	if a.Start.Byte == b.Start.Byte {
+		return true
+	}
+	return a.Start.Byte < b.End.Byte && b.Start.Byte < a.End.Byte
+}
+
+func applyEdits(src []byte, edits []TextEdit) ([]byte, error) {
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Range.Start.Byte < edits[j].Range.Start.Byte
+	})
+
+	var ret bytes.Buffer
+	offset := 0
+	for _, edit := range edits {
+		if edit.Range.Start.Byte < offset || edit.Range.End.Byte > len(src) || edit.Range.Start.Byte > edit.Range.End.Byte {
+			return nil, fmt.Errorf("Invalid edit range: %s", edit.Range)
+		}
+		ret.Write(src[offset:edit.Range.Start.Byte])
+		ret.WriteString(edit.NewText)
+		offset = edit.Range.End.Byte
+	}
+	ret.Write(src[offset:])
+
+	return ret.Bytes(), nil
+}
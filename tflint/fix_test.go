@@ -0,0 +1,57 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_ApplyFixes(t *testing.T) {
+	src := `resource "aws_instance" "web" {
+  instance_type = "t1.2xlarge"
+  ami           = "ami-1234"
+}
+`
+	rng := func(start, end int) hcl.Range {
+		return hcl.Range{Filename: "main.tf", Start: hcl.Pos{Byte: start}, End: hcl.Pos{Byte: end}}
+	}
+
+	// `"t1.2xlarge"` is at 50-62, and `"ami-1234"` is at 81-91
+	fixes := []*Fix{
+		{Rule: "instance_type", Edits: []TextEdit{{Range: rng(50, 62), NewText: `"t2.micro"`}}},
+		{Rule: "ami", Edits: []TextEdit{{Range: rng(81, 91), NewText: `"ami-5678"`}}},
+		{Rule: "conflict", Edits: []TextEdit{{Range: rng(55, 60), NewText: "x"}}},
+	}
+
+	fixed, conflicts, err := ApplyFixes(map[string][]byte{"main.tf": []byte(src)}, fixes)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := `resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+  ami           = "ami-5678"
+}
+`
+	if string(fixed["main.tf"]) != expected {
+		t.Fatalf("Unexpected result: %s", cmp.Diff(expected, string(fixed["main.tf"])))
+	}
+	if len(conflicts) != 1 || conflicts[0].Fix.Rule != "conflict" || conflicts[0].With.Rule != "instance_type" {
+		t.Fatalf("Unexpected conflicts: %#v", conflicts)
+	}
+
+	diff, _, err := PreviewFixes(map[string][]byte{"main.tf": []byte(src)}, fixes[:1])
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	expectedDiff := `--- main.tf
++++ main.tf
+@@ -2,1 +2,1 @@
+-  instance_type = "t1.2xlarge"
++  instance_type = "t2.micro"
+`
+	if diff != expectedDiff {
+		t.Fatalf("Unexpected diff: %s", cmp.Diff(expectedDiff, diff))
+	}
+}
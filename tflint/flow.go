@@ -0,0 +1,82 @@
+package tflint
+
+import (
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// VariableFlow is the flow of an argument of a module call into the child module.
+type VariableFlow struct {
+	// Module is the name of the module call.
+	Module string
+	// Argument is the argument in the module block of the calling module.
+	Argument *hcl.Attribute
+	// Variable is the variable that the argument feeds in the child module. It is nil if not declared (e.g. typos).
+	Variable *Variable
+	// References is the list of ranges in the child module where the variable is referred to (e.g. `var.name`).
+	References []hcl.Range
+	// Outputs is the list of outputs in the child module whose values refer to the variable.
+	Outputs []*Output
+}
+
+// NewVariableFlows returns the flows of the arguments of the passed module call.
+// The child module is given as its variables, outputs and the expressions of all attributes.
+// Flows are sorted by argument name.
+func NewVariableFlows(call *ModuleCall, variables []*Variable, outputs []*Output, exprs []hcl.Expression) []*VariableFlow {
+	flows := []*VariableFlow{}
+	for name, argument := range call.Inputs {
+		flow := &VariableFlow{
+			Module:     call.Name,
+			Argument:   argument,
+			References: []hcl.Range{},
+			Outputs:    []*Output{},
+		}
+
+		for _, variable := range variables {
+			if variable.Name == name {
+				flow.Variable = variable
+			}
+		}
+		for _, expr := range exprs {
+			for _, traversal := range expr.Variables() {
+				if variableName(traversal) == name {
+					flow.References = append(flow.References, traversal.SourceRange())
+				}
+			}
+		}
+		for _, output := range outputs {
+			if output.Value != nil && refersToVariable(output.Value.Expr, name) {
+				flow.Outputs = append(flow.Outputs, output)
+			}
+		}
+
+		flows = append(flows, flow)
+	}
+
+	sort.Slice(flows, func(i, j int) bool {
+		return flows[i].Argument.Name < flows[j].Argument.Name
+	})
+	return flows
+}
+
+// variableName returns the name of the variable referred to by the passed traversal (e.g. `name` for `var.name`).
+// An empty string is returned if it does not refer to variables.
+func variableName(traversal hcl.Traversal) string {
+	if traversal.RootName() != "var" || len(traversal) < 2 {
+		return ""
+	}
+	if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+		return attr.Name
+	}
+	return ""
+}
+
+func refersToVariable(expr hcl.Expression, name string) bool {
+	for _, traversal := range expr.Variables() {
+		if variableName(traversal) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package tflint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// UnknownPlaceholder is a symbol used by FoldExpr for parts that cannot be rendered as source text.
+const UnknownPlaceholder = "<unknown>"
+
+// FoldExpr returns a constant-folded representation of the passed expression.
+// Parts that can be evaluated are resolved, and the rest are left symbolic as interpolation sequences.
+// For example, `"${var.env}-${var.suffix}"` resolves to `prod-${var.suffix}` if only `var.suffix` is unknown.
+// This is useful for better messages when the expression cannot be evaluated completely.
+//
+// Errors other than warnings (e.g. unknown values) are returned as is.
+func FoldExpr(runner Runner, expr hcl.Expression) (string, error) {
+	var val string
+	err := runner.EvaluateExpr(expr, &val)
+	if err == nil {
+		return val, nil
+	}
+	var appErr Error
+	if !errors.As(err, &appErr) || appErr.Level != WarningLevel {
+		return "", err
+	}
+
+	switch expr := expr.(type) {
+	case *hclsyntax.TemplateWrapExpr:
+		return FoldExpr(runner, expr.Wrapped)
+	case *hclsyntax.TemplateExpr:
+		var b strings.Builder
+		for _, part := range expr.Parts {
+			folded, err := FoldExpr(runner, part)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(folded)
+		}
+		return b.String(), nil
+	case *hclsyntax.ScopeTraversalExpr:
+		return fmt.Sprintf("${%s}", traversalString(expr.Traversal)), nil
+	default:
+		return UnknownPlaceholder, nil
+	}
+}
+
+func traversalString(traversal hcl.Traversal) string {
+	var b strings.Builder
+	for _, step := range traversal {
+		switch step := step.(type) {
+		case hcl.TraverseRoot:
+			b.WriteString(step.Name)
+		case hcl.TraverseAttr:
+			b.WriteString("." + step.Name)
+		case hcl.TraverseIndex:
+			switch {
+			case !step.Key.IsKnown() || step.Key.IsNull():
+				b.WriteString("[" + UnknownPlaceholder + "]")
+			case step.Key.Type() == cty.String:
+				b.WriteString(fmt.Sprintf("[%q]", step.Key.AsString()))
+			case step.Key.Type() == cty.Number:
+				b.WriteString("[" + step.Key.AsBigFloat().Text('f', -1) + "]")
+			default:
+				b.WriteString("[" + UnknownPlaceholder + "]")
+			}
+		case hcl.TraverseSplat:
+			b.WriteString("[*]")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package tflint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_FoldExpr(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected string
+	}{
+		{
+			Name:     "literal",
+			Value:    `"prod"`,
+			Expected: "prod",
+		},
+		{
+			Name:     "known template",
+			Value:    `"${var.env}-web"`,
+			Expected: "prod-web",
+		},
+		{
+			Name:     "partially unknown template",
+			Value:    `"${var.env}-${var.suffix}"`,
+			Expected: "prod-${var.suffix}",
+		},
+		{
+			Name:     "unknown traversal",
+			Value:    `var.suffix`,
+			Expected: "${var.suffix}",
+		},
+		{
+			Name:     "unknown conditional",
+			Value:    `"${var.env}-${var.suffix == "" ? "a" : "b"}"`,
+			Expected: "prod-<unknown>",
+		},
+	}
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"main.tf": `
+variable "env" {
+  default = "prod"
+}
+
+variable "suffix" {}
+
+resource "aws_instance" "web" {
+  name = ` + tc.Value + `
+}`})
+
+		err := runner.WalkResourceAttributes("aws_instance", "name", func(attribute *hcl.Attribute) error {
+			got, err := tflint.FoldExpr(runner, attribute.Expr)
+			if err != nil {
+				return err
+			}
+			if got != tc.Expected {
+				t.Fatalf("Failed `%s` test: expected %s, but got %s", tc.Name, tc.Expected, got)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+	}
+}
+
+// wrappingRunner wraps errors of EvaluateExpr like rules and middlewares often do
+type wrappingRunner struct {
+	*helper.Runner
+}
+
+func (r *wrappingRunner) EvaluateExpr(expr hcl.Expression, ret interface{}) error {
+	if err := r.Runner.EvaluateExpr(expr, ret); err != nil {
+		return fmt.Errorf("wrapped: %w", err)
+	}
+	return nil
+}
+
+func Test_FoldExpr_wrapped(t *testing.T) {
+	runner := &wrappingRunner{helper.TestRunner(t, map[string]string{"main.tf": `
+variable "env" {
+  default = "prod"
+}
+
+variable "suffix" {}
+
+resource "aws_instance" "web" {
+  name = "${var.env}-${var.suffix}"
+}`})}
+
+	err := runner.WalkResourceAttributes("aws_instance", "name", func(attribute *hcl.Attribute) error {
+		got, err := tflint.FoldExpr(runner, attribute.Expr)
+		if err != nil {
+			return err
+		}
+		if got != "prod-${var.suffix}" {
+			t.Fatalf("Expected prod-${var.suffix}, but got %s", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
@@ -0,0 +1,65 @@
+package tflint
+
+import (
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// FunctionCall is a function call found in the configuration.
+type FunctionCall struct {
+	Name      string
+	Range     hcl.Range
+	NameRange hcl.Range
+	// ArgRanges is a list of ranges of each argument.
+	ArgRanges []hcl.Range
+
+	// BlockType is the type of the top-level block containing the call (e.g. "resource").
+	BlockType string
+	// BlockLabels is the labels of the top-level block containing the call (e.g. ["aws_instance", "web"]).
+	BlockLabels []string
+	// BlockRange is the range of the header of the top-level block containing the call.
+	BlockRange hcl.Range
+}
+
+// FindFunctionCalls returns all function calls in the passed file in source order.
+// Only files in native syntax are supported, because JSON syntax has no function calls outside of templates.
+func FindFunctionCalls(file *hcl.File) []*FunctionCall {
+	calls := []*FunctionCall{}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return calls
+	}
+
+	for _, block := range body.Blocks {
+		hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(*hclsyntax.FunctionCallExpr)
+			if !ok {
+				return nil
+			}
+
+			args := make([]hcl.Range, len(expr.Args))
+			for i, arg := range expr.Args {
+				args[i] = arg.Range()
+			}
+			calls = append(calls, &FunctionCall{
+				Name:        expr.Name,
+				Range:       expr.Range(),
+				NameRange:   expr.NameRange,
+				ArgRanges:   args,
+				BlockType:   block.Type,
+				BlockLabels: block.Labels,
+				BlockRange:  block.DefRange(),
+			})
+			return nil
+		})
+	}
+
+	// Attributes are visited in random order because they are stored in a map.
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].Range.Start.Byte < calls[j].Range.Start.Byte
+	})
+	return calls
+}
@@ -0,0 +1,72 @@
+package tflint
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchGlob reports whether the passed filename matches the glob pattern.
+// Filenames are slash-separated paths relative to the module root (see NormalizePath).
+// The pattern syntax is the same as path.Match, except that `**` matches zero or more directories
+// (e.g. `modules/**/*.tf`).
+func MatchGlob(pattern, filename string) (bool, error) {
+	// Check the syntax up front, because path.Match may not report errors if the name does not match.
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false, err
+		}
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(filename, "/")), nil
+}
+
+func matchSegments(patterns, names []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(names) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(patterns[0], names[0]); !matched {
+			return false
+		}
+		patterns, names = patterns[1:], names[1:]
+	}
+	return len(names) == 0
+}
+
+// MatchResourceType reports whether the passed resource type matches the pattern.
+// The pattern is a resource type (e.g. `aws_instance`) or a glob in path.Match syntax (e.g. `aws_*`).
+// Malformed patterns match nothing.
+func MatchResourceType(pattern, resourceType string) bool {
+	if pattern == resourceType {
+		return true
+	}
+	matched, err := path.Match(pattern, resourceType)
+	return err == nil && matched
+}
+
+// MatchResourceAddress reports whether the resource with the passed type and name matches the pattern.
+// The pattern is a resource type pattern like MatchResourceType, optionally followed by a name (e.g. `aws_instance.web`).
+// The name can also be a glob pattern (e.g. `aws_instance.web_*`).
+func MatchResourceAddress(pattern, resourceType, name string) bool {
+	typePattern, namePattern := pattern, ""
+	if i := strings.Index(pattern, "."); i >= 0 {
+		typePattern, namePattern = pattern[:i], pattern[i+1:]
+	}
+
+	if !MatchResourceType(typePattern, resourceType) {
+		return false
+	}
+	if namePattern == "" || namePattern == name {
+		return true
+	}
+	matched, err := path.Match(namePattern, name)
+	return err == nil && matched
+}
@@ -0,0 +1,80 @@
+package tflint
+
+import "testing"
+
+func Test_MatchGlob(t *testing.T) {
+	cases := []struct {
+		Pattern  string
+		Filename string
+		Expected bool
+	}{
+		{Pattern: "*.tfvars", Filename: "terraform.tfvars", Expected: true},
+		{Pattern: "*.tfvars", Filename: "envs/prod.tfvars", Expected: false},
+		{Pattern: "modules/**/*.tf", Filename: "modules/vpc/main.tf", Expected: true},
+		{Pattern: "modules/**/*.tf", Filename: "modules/main.tf", Expected: true},
+		{Pattern: "modules/**/*.tf", Filename: "modules/vpc/subnet/main.tf", Expected: true},
+		{Pattern: "modules/**/*.tf", Filename: "main.tf", Expected: false},
+		{Pattern: "**/*.tfvars", Filename: "modules/vpc/default.tfvars", Expected: true},
+		{Pattern: "**", Filename: "main.tf", Expected: true},
+	}
+
+	for _, tc := range cases {
+		got, err := MatchGlob(tc.Pattern, tc.Filename)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` matching `%s`: expected %t, but got %t", tc.Pattern, tc.Filename, tc.Expected, got)
+		}
+	}
+
+	if _, err := MatchGlob("[", "main.tf"); err == nil {
+		t.Fatal("Expected an error for a malformed pattern, but got nil")
+	}
+}
+
+func Test_MatchResourceType(t *testing.T) {
+	cases := []struct {
+		Pattern  string
+		Type     string
+		Expected bool
+	}{
+		{Pattern: "aws_instance", Type: "aws_instance", Expected: true},
+		{Pattern: "aws_instance", Type: "aws_instances", Expected: false},
+		{Pattern: "aws_*", Type: "aws_s3_bucket", Expected: true},
+		{Pattern: "aws_*", Type: "google_compute_instance", Expected: false},
+		{Pattern: "*", Type: "azurerm_resource_group", Expected: true},
+		{Pattern: "aws_[", Type: "aws_instance", Expected: false},
+	}
+
+	for _, tc := range cases {
+		got := MatchResourceType(tc.Pattern, tc.Type)
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` matching `%s`: expected %t, but got %t", tc.Pattern, tc.Type, tc.Expected, got)
+		}
+	}
+}
+
+func Test_MatchResourceAddress(t *testing.T) {
+	cases := []struct {
+		Pattern  string
+		Type     string
+		Name     string
+		Expected bool
+	}{
+		{Pattern: "aws_instance", Type: "aws_instance", Name: "web", Expected: true},
+		{Pattern: "aws_instance.web", Type: "aws_instance", Name: "web", Expected: true},
+		{Pattern: "aws_instance.web", Type: "aws_instance", Name: "db", Expected: false},
+		{Pattern: "aws_instance.web", Type: "aws_s3_bucket", Name: "web", Expected: false},
+		{Pattern: "aws_instance.web_*", Type: "aws_instance", Name: "web_1", Expected: true},
+		{Pattern: "aws_*.web", Type: "aws_s3_bucket", Name: "web", Expected: true},
+		{Pattern: "aws_instance.[", Type: "aws_instance", Name: "web", Expected: false},
+	}
+
+	for _, tc := range cases {
+		got := MatchResourceAddress(tc.Pattern, tc.Type, tc.Name)
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` matching `%s.%s`: expected %t, but got %t", tc.Pattern, tc.Type, tc.Name, tc.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package tflint
+
+import (
+	"strconv"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// IssueGroup collects locations of the same finding and emits them as a single issue.
+// It allows rules like "duplicate tag key used in 14 resources" to produce one readable issue instead of 14.
+//
+// Example:
+//
+//	group := tflint.NewIssueGroup(rule, "Tag key `Name` is used in {{count}} resources")
+//	for _, resource := range resources {
+//		group.Add(resource.DeclRange)
+//	}
+//	return group.Emit(runner)
+type IssueGroup struct {
+	rule      Rule
+	message   string
+	locations []hcl.Range
+}
+
+// NewIssueGroup returns a new group. In the message, `{{count}}` is replaced with the number of occurrences.
+func NewIssueGroup(rule Rule, message string) *IssueGroup {
+	return &IssueGroup{rule: rule, message: message, locations: []hcl.Range{}}
+}
+
+// Add adds an occurrence at the passed location.
+func (g *IssueGroup) Add(location hcl.Range) {
+	g.locations = append(g.locations, location)
+}
+
+// Len returns the number of occurrences.
+func (g *IssueGroup) Len() int {
+	return len(g.locations)
+}
+
+// Emit emits the group as a single issue located at the first occurrence, with all occurrences in Metadata.Occurrences.
+// Nothing is emitted if there are no occurrences.
+func (g *IssueGroup) Emit(runner Runner) error {
+	if len(g.locations) == 0 {
+		return nil
+	}
+
+	message := strings.Replace(g.message, "{{count}}", strconv.Itoa(len(g.locations)), -1)
+	return runner.EmitIssue(g.rule, message, g.locations[0], Metadata{Occurrences: g.locations})
+}
@@ -0,0 +1,57 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+type emittedIssue struct {
+	Message  string
+	Location hcl.Range
+	Meta     Metadata
+}
+
+// issueRecorder is a Runner that only records emitted issues.
+type issueRecorder struct {
+	Runner
+	issues []emittedIssue
+}
+
+func (r *issueRecorder) EmitIssue(rule Rule, message string, location hcl.Range, meta Metadata) error {
+	r.issues = append(r.issues, emittedIssue{Message: message, Location: location, Meta: meta})
+	return nil
+}
+
+func Test_IssueGroup(t *testing.T) {
+	runner := &issueRecorder{}
+
+	empty := NewIssueGroup(&testRule{}, "Tag key `Name` is used in {{count}} resources")
+	if err := empty.Emit(runner); err != nil {
+		t.Fatal(err)
+	}
+	if len(runner.issues) != 0 {
+		t.Fatalf("Expected no issues, but got %#v", runner.issues)
+	}
+
+	group := NewIssueGroup(&testRule{}, "Tag key `Name` is used in {{count}} resources")
+	first := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1}}
+	second := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 5}}
+	group.Add(first)
+	group.Add(second)
+	if err := group.Emit(runner); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []emittedIssue{
+		{
+			Message:  "Tag key `Name` is used in 2 resources",
+			Location: first,
+			Meta:     Metadata{Occurrences: []hcl.Range{first, second}},
+		},
+	}
+	if !cmp.Equal(expected, runner.issues) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, runner.issues))
+	}
+}
@@ -1,15 +1,75 @@
+// Package tflint contains the Runner interface used by rules and the types exchanged between plugins and the host.
+// Constructors of these types (e.g. NewResource, FindFunctionCalls) are mainly for hosts to build responses.
 package tflint
 
 import (
+	"context"
+	"math/rand"
+
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Runner acts as a client for each plugin to query the host process about the Terraform configurations.
+//
+// Values passed to walker functions are owned by the Runner and are valid only until the walker returns.
+// Copy them if you need to retain them. Attributes and blocks are never shared with other walks, so modifying their fields
+// (e.g. ranges) is safe, but expressions and bodies may be shared among rules and concurrent walks and must not be modified.
+//
+// Resource types passed to resource walkers can be glob patterns (e.g. `aws_*`), optionally followed by a resource name
+// (e.g. `aws_instance.web`) to scope the walk to specific resources. Filtering is done by the host. See MatchResourceAddress.
+//
+// A module without any Terraform files (e.g. a directory containing only scripts in a monorepo) is not an error.
+// Walkers invoke nothing, counts are 0 and queries return empty results. Use IsEmpty to tell this case apart.
 type Runner interface {
 	WalkResourceAttributes(string, string, func(*hcl.Attribute) error) error
+	WalkResourceAttributesWithFields(string, string, AttributeFields, func(*hcl.Attribute) error) error
+	WalkResourceAttributeValues(string, string, func(*hcl.Attribute, cty.Value) error) error
+	GetDuplicateValues(string, string) ([]*DuplicateGroup, error)
+	WalkResourceBlocks(string, string, func(*hcl.Block) error) error
+	WalkExpandedResourceBlocks(string, string, func(*ExpandedBlock) error) error
+	WalkDataSourceAttributes(string, string, func(*hcl.Attribute) error) error
+	WalkAttributeOrder(string, func(*AttributeOrder) error) error
+	CountResources(string) (int, error)
+	CountBlocks(string, string) (int, error)
+	GetModuleContent(*hcl.BodySchema) (*hcl.BodyContent, error)
+	GetResourceAttributeNames(string) ([]*ResourceAttributeNames, error)
+	WalkResources(string, func(*Resource) error) error
+	WalkResourceMetaArguments(string, func(*MetaArguments) error) error
+	WalkResourceProvisioners(string, func(*Provisioner) error) error
+	WalkLocals(func(string, hcl.Expression) error) error
+	WalkVariables(func(*Variable) error) error
+	WalkOutputs(func(*Output) error) error
+	WalkMovedBlocks(func(*Moved) error) error
+	WalkImportBlocks(func(*Import) error) error
+	WalkConditions(func(*Condition) error) error
+	WalkTerraformSettings(func(*TerraformSettings) error) error
+	Backend() (*Backend, error)
+	WalkModuleCalls(func(*ModuleCall) error) error
+	GetVariableFlows(module string) ([]*VariableFlow, error)
+	WalkProviderConfigs(func(*ProviderConfig) error) error
+	GetFunctionCalls() ([]*FunctionCall, error)
+	WalkExpressions(func(hcl.Expression) error) error
+	GetFiles(pattern string) ([]string, error)
+	GetVariableFiles() ([]*VariableFile, error)
 	EvaluateExpr(expr hcl.Expression, ret interface{}) error
+	IsNullExpr(expr hcl.Expression) (bool, error)
+	IsSensitive(expr hcl.Expression) (bool, error)
+	ExprSource(expr hcl.Expression) (string, error)
+	GetProviderConfigValue(provider string, name string, ret interface{}) (bool, error)
 	EmitIssue(rule Rule, message string, location hcl.Range, meta Metadata) error
+	EmitRecord(rule Rule, value cty.Value, location hcl.Range) error
+	ReportProgress(rule Rule, percent int, message string) error
 	EnsureNoError(error, func() error) error
+	RunMetadata() (*RunMetadata, error)
+	Clock() (Clock, error)
+	Rand(rule Rule) (*rand.Rand, error)
+	ModulePath() (ModulePath, error)
+	ModuleName() (string, error)
+	IsEmpty() (bool, error)
+	LookupEnv(name string) (string, bool, error)
+	IsOffline() bool
+	Context() context.Context
 }
 
 // Rule is the interface that the plugin's rules should satisfy.
@@ -24,6 +84,43 @@ type Rule interface {
 // Server is the interface that hosts that provide the plugin mechanism must meet in order to respond to queries from the plugin.
 type Server interface {
 	Attributes(*AttributesRequest, *AttributesResponse) error
+	AttributeValues(*AttributesRequest, *AttributeValuesResponse) error
+	DuplicateValues(*AttributesRequest, *DuplicateValuesResponse) error
+	Blocks(*BlocksRequest, *BlocksResponse) error
+	Prefetch(*PrefetchRequest, *PrefetchResponse) error
+	ExpandedBlocks(*BlocksRequest, *ExpandedBlocksResponse) error
+	DataSourceAttributes(*DataSourceAttributesRequest, *DataSourceAttributesResponse) error
+	AttributeOrder(*AttributeOrderRequest, *AttributeOrderResponse) error
+	Count(*CountRequest, *CountResponse) error
+	ModuleContent(*ModuleContentRequest, *ModuleContentResponse) error
+	ResourceAttributeNames(*ResourceAttributeNamesRequest, *ResourceAttributeNamesResponse) error
+	Resources(*ResourcesRequest, *ResourcesResponse) error
+	MetaArguments(*MetaArgumentsRequest, *MetaArgumentsResponse) error
+	Provisioners(*ProvisionersRequest, *ProvisionersResponse) error
+	Locals(interface{}, *LocalsResponse) error
+	Variables(interface{}, *VariablesResponse) error
+	Outputs(interface{}, *OutputsResponse) error
+	MovedBlocks(interface{}, *MovedBlocksResponse) error
+	ImportBlocks(interface{}, *ImportBlocksResponse) error
+	Conditions(interface{}, *ConditionsResponse) error
+	TerraformSettings(interface{}, *TerraformSettingsResponse) error
+	Backend(interface{}, *BackendResponse) error
+	ModuleCalls(interface{}, *ModuleCallsResponse) error
+	VariableFlows(*VariableFlowsRequest, *VariableFlowsResponse) error
+	ProviderConfigs(interface{}, *ProviderConfigsResponse) error
+	Annotations(interface{}, *AnnotationsResponse) error
+	FunctionCalls(interface{}, *FunctionCallsResponse) error
+	Expressions(interface{}, *ExpressionsResponse) error
+	Files(*FilesRequest, *FilesResponse) error
+	VariableFiles(interface{}, *VariableFilesResponse) error
 	EvalExpr(*EvalExprRequest, *EvalExprResponse) error
+	Source(*SourceRequest, *SourceResponse) error
+	ProviderConfig(*ProviderConfigRequest, *ProviderConfigResponse) error
 	EmitIssue(*EmitIssueRequest, *interface{}) error
+	EmitRecord(*EmitRecordRequest, *interface{}) error
+	Progress(*ProgressRequest, *interface{}) error
+	RunMetadata(interface{}, *RunMetadataResponse) error
+	ModulePath(interface{}, *ModulePathResponse) error
+	IsEmpty(interface{}, *IsEmptyResponse) error
+	Env(*EnvRequest, *EnvResponse) error
 }
@@ -14,6 +14,16 @@ const (
 // Metadata is the additional data sent to the host process to build the issue.
 type Metadata struct {
 	Expr hcl.Expression
+	// Fix is the fix that resolves the issue. Optional.
+	Fix *Fix
+	// Localizable identifies the message by ID and arguments for rendering in the user's locale. Optional.
+	// The message passed to EmitIssue is still used as the default text.
+	Localizable *LocalizableMessage
+	// Remediations are hints on how to resolve the issue, used when a Fix is not computable. Optional.
+	Remediations []Remediation
+	// Occurrences is a list of all locations of the finding when the issue groups multiple occurrences. Optional.
+	// The number of occurrences is the count of the finding. See also IssueGroup.
+	Occurrences []hcl.Range
 }
 
 // RuleObject is an intermediate representation for communicating with RPC.
@@ -29,13 +39,18 @@ type RuleObjectData struct {
 	Link     string
 }
 
-func newObjectFromRule(rule Rule) *RuleObject {
+func newObjectFromRule(rule Rule, linker func(Rule) string) *RuleObject {
+	link := rule.Link()
+	if linker != nil {
+		link = linker(rule)
+	}
+
 	return &RuleObject{
 		Data: &RuleObjectData{
 			Name:     rule.Name(),
 			Enabled:  rule.Enabled(),
 			Severity: rule.Severity(),
-			Link:     rule.Link(),
+			Link:     link,
 		},
 	}
 }
@@ -51,3 +66,7 @@ func (r *RuleObject) Severity() string { return r.Data.Severity }
 
 // Link is a reference method to internal data
 func (r *RuleObject) Link() string { return r.Data.Link }
+
+// Check is a dummy method to satisfy the Rule interface.
+// RuleObject is only used to transfer rule metadata, so it never inspects anything.
+func (r *RuleObject) Check(Runner) error { return nil }
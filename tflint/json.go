@@ -0,0 +1,135 @@
+package tflint
+
+import (
+	"encoding/json"
+	"errors"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ContentToMap converts the fetched content (e.g. the result of GetModuleContent) into a plain JSON-compatible map.
+// This is useful for delegating decisions to external engines and webhooks, or for snapshot-style tests.
+//
+// Attributes are evaluated via the Runner, and values that cannot be evaluated (e.g. unknown) become null.
+// Blocks are nested by their labels, and blocks without labels become lists. If several blocks have the same labels
+// (e.g. provider aliases), they are collected into a list. For example:
+//
+//	{
+//	  "resource": {"aws_instance": {"web": {"instance_type": "t2.micro", "ebs_block_device": [{"volume_size": 8}]}}},
+//	  "provider": {"aws": [{"region": "us-east-1"}, {"alias": "west", "region": "us-west-2"}]}
+//	}
+//
+// Note that nested blocks are converted only for native syntax bodies.
+func ContentToMap(runner Runner, content *hcl.BodyContent) (map[string]interface{}, error) {
+	ret := map[string]interface{}{}
+
+	for name, attribute := range content.Attributes {
+		val, err := evaluateToJSONCompatible(runner, attribute.Expr)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = val
+	}
+
+	for _, block := range content.Blocks {
+		body, err := bodyToMap(runner, block.Body)
+		if err != nil {
+			return nil, err
+		}
+		insertBlock(ret, block.Type, block.Labels, body)
+	}
+
+	return ret, nil
+}
+
+// ContentToJSON is the same as ContentToMap, but returns the JSON document.
+// Keys are sorted, so the output is stable for snapshot tests.
+func ContentToJSON(runner Runner, content *hcl.BodyContent) ([]byte, error) {
+	ret, err := ContentToMap(runner, content)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(ret, "", "  ")
+}
+
+func bodyToMap(runner Runner, body hcl.Body) (map[string]interface{}, error) {
+	ret := map[string]interface{}{}
+
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return ret, nil
+	}
+
+	for name, attribute := range syntaxBody.Attributes {
+		val, err := evaluateToJSONCompatible(runner, attribute.Expr)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = val
+	}
+
+	for _, block := range syntaxBody.Blocks {
+		nested, err := bodyToMap(runner, block.Body)
+		if err != nil {
+			return nil, err
+		}
+		insertBlock(ret, block.Type, block.Labels, nested)
+	}
+
+	return ret, nil
+}
+
+func insertBlock(parent map[string]interface{}, blockType string, labels []string, body map[string]interface{}) {
+	if len(labels) == 0 {
+		list, _ := parent[blockType].([]interface{})
+		parent[blockType] = append(list, body)
+		return
+	}
+
+	child, ok := parent[blockType].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		parent[blockType] = child
+	}
+
+	if len(labels) > 1 {
+		insertBlock(child, labels[0], labels[1:], body)
+		return
+	}
+
+	switch existing := child[labels[0]].(type) {
+	case nil:
+		child[labels[0]] = body
+	case []interface{}:
+		child[labels[0]] = append(existing, body)
+	default:
+		child[labels[0]] = []interface{}{existing, body}
+	}
+}
+
+func evaluateToJSONCompatible(runner Runner, expr hcl.Expression) (interface{}, error) {
+	var val cty.Value
+	if err := runner.EvaluateExpr(expr, &val); err != nil {
+		var appErr Error
+		if errors.As(err, &appErr) && appErr.Level == WarningLevel {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !val.IsWhollyKnown() {
+		return nil, nil
+	}
+
+	src, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return nil, err
+	}
+	var ret interface{}
+	if err := json.Unmarshal(src, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,109 @@
+package tflint_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_ContentToJSON(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+variable "size" {}
+
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}
+
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+  volume_size   = var.size
+
+  ebs_block_device {
+    device_name = "/dev/sdb"
+  }
+}`})
+
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "provider", LabelNames: []string{"name"}},
+			{Type: "resource", LabelNames: []string{"type", "name"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tflint.ContentToJSON(runner, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+  "provider": {
+    "aws": [
+      {
+        "region": "us-east-1"
+      },
+      {
+        "alias": "west",
+        "region": "us-west-2"
+      }
+    ]
+  },
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "ebs_block_device": [
+          {
+            "device_name": "/dev/sdb"
+          }
+        ],
+        "instance_type": "t2.micro",
+        "volume_size": null
+      }
+    }
+  }
+}`
+	if string(got) != expected {
+		t.Fatalf("Expected:\n%s\n\nGot:\n%s", expected, got)
+	}
+}
+
+func Test_ContentToJSON_wrapped(t *testing.T) {
+	runner := &wrappingRunner{helper.TestRunner(t, map[string]string{"main.tf": `
+resource "aws_instance" "web" {
+  ami = data.aws_ami.ubuntu.id
+}`})}
+
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tflint.ContentToJSON(runner, content)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := `{
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "ami": null
+      }
+    }
+  }
+}`
+	if string(got) != expected {
+		t.Fatalf("Expected:\n%s\n\nGot:\n%s", expected, got)
+	}
+}
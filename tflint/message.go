@@ -0,0 +1,57 @@
+package tflint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is the locale used when the catalog has no templates for the requested locale.
+const DefaultLocale = "en"
+
+// LocalizableMessage identifies an issue message by ID with its arguments.
+// It is sent separately from the rendered text, so hosts can render the message in the user's locale
+// and downstream tools can categorize issues by ID instead of matching strings.
+type LocalizableMessage struct {
+	ID   string
+	Args map[string]string
+}
+
+// MessageCatalog is a set of message templates keyed by locale and message ID.
+// In templates, `{{name}}` is replaced with the argument of the same name.
+//
+// Example:
+//
+//	tflint.MessageCatalog{
+//		"en": {"invalid_type": `"{{type}}" is an invalid instance type`},
+//		"ja": {"invalid_type": `"{{type}}" は無効なインスタンスタイプです`},
+//	}
+type MessageCatalog map[string]map[string]string
+
+// Render renders the message in the passed locale.
+// If the locale has no template for the message, the template of DefaultLocale is used.
+// An error is returned if no template is found.
+func (c MessageCatalog) Render(locale string, msg *LocalizableMessage) (string, error) {
+	template, exists := c[locale][msg.ID]
+	if !exists {
+		template, exists = c[DefaultLocale][msg.ID]
+	}
+	if !exists {
+		return "", fmt.Errorf("Message not found: %s", msg.ID)
+	}
+	return msg.render(template), nil
+}
+
+func (m *LocalizableMessage) render(template string) string {
+	names := make([]string, 0, len(m.Args))
+	for name := range m.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	oldnew := make([]string, 0, len(names)*2)
+	for _, name := range names {
+		oldnew = append(oldnew, "{{"+name+"}}", m.Args[name])
+	}
+	return strings.NewReplacer(oldnew...).Replace(template)
+}
@@ -0,0 +1,64 @@
+package tflint
+
+import "testing"
+
+func Test_MessageCatalog_Render(t *testing.T) {
+	catalog := MessageCatalog{
+		"en": {
+			"invalid_type": `"{{type}}" is an invalid instance type`,
+			"deprecated":   `"{{old}}" is deprecated. Use "{{new}}" instead`,
+		},
+		"ja": {
+			"invalid_type": `"{{type}}" は無効なインスタンスタイプです`,
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Locale   string
+		Message  *LocalizableMessage
+		Expected string
+		Err      string
+	}{
+		{
+			Name:     "default locale",
+			Locale:   "en",
+			Message:  &LocalizableMessage{ID: "invalid_type", Args: map[string]string{"type": "t1.2xlarge"}},
+			Expected: `"t1.2xlarge" is an invalid instance type`,
+		},
+		{
+			Name:     "translated",
+			Locale:   "ja",
+			Message:  &LocalizableMessage{ID: "invalid_type", Args: map[string]string{"type": "t1.2xlarge"}},
+			Expected: `"t1.2xlarge" は無効なインスタンスタイプです`,
+		},
+		{
+			Name:     "fallback to default locale",
+			Locale:   "ja",
+			Message:  &LocalizableMessage{ID: "deprecated", Args: map[string]string{"old": "foo", "new": "bar"}},
+			Expected: `"foo" is deprecated. Use "bar" instead`,
+		},
+		{
+			Name:    "not found",
+			Locale:  "en",
+			Message: &LocalizableMessage{ID: "unknown"},
+			Err:     "Message not found: unknown",
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := catalog.Render(tc.Locale, tc.Message)
+		if tc.Err != "" {
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("Failed `%s` test: expected error `%s`, but got `%v`", tc.Name, tc.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, err)
+		}
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected `%s`, but got `%s`", tc.Name, tc.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// MetaArguments is the set of meta-arguments declared in a resource.
+// Each field is nil if the meta-argument is not declared.
+type MetaArguments struct {
+	Type      string
+	Name      string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the resource block.
+	Ranges BlockRanges
+
+	Count     *hcl.Attribute
+	ForEach   *hcl.Attribute
+	Provider  *hcl.Attribute
+	DependsOn *hcl.Attribute
+	// Lifecycle is the `lifecycle` block. If declared multiple times, the first one is set.
+	Lifecycle *hcl.Block
+}
+
+// metaArgumentsSchema is the schema of meta-arguments in resources
+var metaArgumentsSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "count"},
+		{Name: "for_each"},
+		{Name: "provider"},
+		{Name: "depends_on"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "lifecycle"},
+	},
+}
+
+// NewMetaArguments extracts meta-arguments from the passed resource block.
+func NewMetaArguments(resource *hcl.Block) (*MetaArguments, hcl.Diagnostics) {
+	content, _, diags := resource.Body.PartialContent(metaArgumentsSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	meta := &MetaArguments{
+		Type:      resource.Labels[0],
+		Name:      resource.Labels[1],
+		DeclRange: resource.DefRange,
+		Ranges:    NewBlockRanges(resource),
+		Count:     content.Attributes["count"],
+		ForEach:   content.Attributes["for_each"],
+		Provider:  content.Attributes["provider"],
+		DependsOn: content.Attributes["depends_on"],
+	}
+	if len(content.Blocks) > 0 {
+		meta.Lifecycle = content.Blocks[0]
+	}
+	return meta, nil
+}
+
+// IsStaticCount returns true if `count` is declared with an expression without references (e.g. `count = 100`).
+// Such counts do not scale with inputs, so rules can flag them as hardcoded.
+func (m *MetaArguments) IsStaticCount() bool {
+	return isStaticAttribute(m.Count)
+}
+
+// IsStaticForEach returns true if `for_each` is declared with an expression without references (e.g. a literal map).
+// Function calls on literals (e.g. `toset(["a", "b"])`) are also static.
+func (m *MetaArguments) IsStaticForEach() bool {
+	return isStaticAttribute(m.ForEach)
+}
+
+// isStaticAttribute returns true if the attribute is declared and its expression has no references
+func isStaticAttribute(attribute *hcl.Attribute) bool {
+	return attribute != nil && len(attribute.Expr.Variables()) == 0
+}
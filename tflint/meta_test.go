@@ -0,0 +1,64 @@
+package tflint
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_MetaArguments_IsStatic(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Src     string
+		Count   bool
+		ForEach bool
+	}{
+		{
+			Name:  "static count",
+			Src:   `count = 100`,
+			Count: true,
+		},
+		{
+			Name: "dynamic count",
+			Src:  `count = var.instance_count`,
+		},
+		{
+			Name:    "static for_each",
+			Src:     `for_each = toset(["a", "b"])`,
+			ForEach: true,
+		},
+		{
+			Name: "dynamic for_each",
+			Src:  `for_each = { for k, v in var.instances : k => v }`,
+		},
+		{
+			Name: "not declared",
+			Src:  `ami = "ami-12345678"`,
+		},
+	}
+
+	for _, tc := range cases {
+		file, diags := hclsyntax.ParseConfig([]byte(`resource "aws_instance" "web" {`+"\n"+tc.Src+"\n}"), "main.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+		})
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		meta, diags := NewMetaArguments(content.Blocks[0])
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+
+		if meta.IsStaticCount() != tc.Count {
+			t.Fatalf("%s: Expected IsStaticCount to be %t", tc.Name, tc.Count)
+		}
+		if meta.IsStaticForEach() != tc.ForEach {
+			t.Fatalf("%s: Expected IsStaticForEach to be %t", tc.Name, tc.ForEach)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package tflint
+
+import "time"
+
+// RunMetadata is the metadata about the current run provided by the host process.
+// Rules can include it in issues or adapt their behavior to it.
+type RunMetadata struct {
+	// StartTime is the time when the host process started the inspection.
+	StartTime time.Time
+	// TFLintVersion is the version of the host process.
+	TFLintVersion string
+	// OS and Arch are the GOOS and GOARCH of the host process.
+	OS   string
+	Arch string
+	// CI reports whether the host process detected a CI environment.
+	CI bool
+	// CIProvider is the name of the detected CI service (e.g. "github-actions") if known.
+	CIProvider string
+	// ModuleRoot is the absolute path of the module root on the host.
+	// Filenames in ranges are relative to it. See also ResolvePath.
+	ModuleRoot string
+	// FixedTime is the time returned by the Clock. If zero, the Clock returns the system time.
+	// Hosts set it to reproduce runs (e.g. in tests).
+	FixedTime time.Time
+	// Seed is the seed of random number generators returned by Rand.
+	// Hosts generate it per run, or fix it to reproduce runs.
+	Seed int64
+}
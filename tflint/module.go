@@ -0,0 +1,32 @@
+package tflint
+
+import "strings"
+
+// ModulePath is the call path of a module from the root module.
+// For example, `module "vpc"` in the root module that calls `module "subnets"` is ["vpc", "subnets"].
+// The path of the root module is empty.
+//
+// The source directory of the module being inspected is RunMetadata.ModuleRoot.
+type ModulePath []string
+
+// IsRoot reports whether the path is of the root module.
+func (p ModulePath) IsRoot() bool {
+	return len(p) == 0
+}
+
+// Name returns the name of the module call, which is the last element of the path.
+// It is empty for the root module.
+func (p ModulePath) Name() string {
+	if p.IsRoot() {
+		return ""
+	}
+	return p[len(p)-1]
+}
+
+// String returns the address of the module (e.g. `module.vpc.module.subnets`), or `root` for the root module.
+func (p ModulePath) String() string {
+	if p.IsRoot() {
+		return "root"
+	}
+	return "module." + strings.Join(p, ".module.")
+}
@@ -0,0 +1,52 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// ModuleCall is a `module` block declared in the module.
+type ModuleCall struct {
+	Name      string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the module block.
+	Ranges BlockRanges
+
+	// Source is the `source` attribute. It is nil if not declared.
+	Source *hcl.Attribute
+	// Version is the `version` attribute. It is nil if not declared.
+	Version *hcl.Attribute
+	// Inputs is the set of input variables keyed by name.
+	// Meta-arguments (source, version, count, for_each, providers, depends_on) are not included.
+	Inputs map[string]*hcl.Attribute
+}
+
+// moduleMetaArguments is the set of attribute names of module blocks that are not input variables
+var moduleMetaArguments = map[string]bool{
+	"source":     true,
+	"version":    true,
+	"count":      true,
+	"for_each":   true,
+	"providers":  true,
+	"depends_on": true,
+}
+
+// NewModuleCall extracts the module call from the passed module block.
+func NewModuleCall(module *hcl.Block) (*ModuleCall, hcl.Diagnostics) {
+	attributes, diags := module.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	call := &ModuleCall{
+		Name:      module.Labels[0],
+		DeclRange: module.DefRange,
+		Ranges:    NewBlockRanges(module),
+		Source:    attributes["source"],
+		Version:   attributes["version"],
+		Inputs:    map[string]*hcl.Attribute{},
+	}
+	for name, attribute := range attributes {
+		if !moduleMetaArguments[name] {
+			call.Inputs[name] = attribute
+		}
+	}
+	return call, nil
+}
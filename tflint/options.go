@@ -0,0 +1,34 @@
+package tflint
+
+import (
+	"io"
+	"log"
+	"time"
+)
+
+// Options is the configuration of the Client. The zero value is the same as NewClient.
+type Options struct {
+	// Timeout is the maximum duration of each RPC call. 0 means no timeout.
+	// A call that timed out fails with ProtocolError wrapping TimeoutError, and its late response is discarded.
+	Timeout time.Duration
+	// Logger receives the debug logs of the Client. If nil, the standard logger is used.
+	Logger Logger
+	// SharedCacheSize is the maximum number of responses shared among rules (see RuleSet.ShareResults).
+	// Responses beyond the limit are not shared. 0 means no limit.
+	SharedCacheSize int
+	// Codec returns the codec used to encode RPC calls. If nil, gob is used (net/rpc's default).
+	// The host must serve the same codec (e.g. net/rpc/jsonrpc).
+	Codec func(io.ReadWriteCloser) ClientCodec
+}
+
+// Logger is the interface that loggers passed to the Client should satisfy. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// standardLogger is a Logger that writes to the standard logger
+type standardLogger struct{}
+
+func (standardLogger) Printf(format string, v ...interface{}) {
+	log.Printf(format, v...)
+}
@@ -0,0 +1,26 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// AttributeOrder is the list of attributes declared in a block, kept in the order of the source.
+type AttributeOrder struct {
+	// DeclRange is the range of the block header (e.g. `resource "aws_instance" "web"`).
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the block.
+	Ranges BlockRanges
+	// Attributes is a list of attributes in declaration order.
+	Attributes []*hcl.Attribute
+	// Duplicates is a list of attributes whose keys are declared more than once.
+	// Only JSON syntax can contain these, as the native syntax rejects duplicate arguments at parse time.
+	// The first declaration is contained in Attributes, and the rest are here.
+	Duplicates []*hcl.Attribute
+}
+
+// Names returns attribute names in declaration order.
+func (o *AttributeOrder) Names() []string {
+	names := make([]string, len(o.Attributes))
+	for i, attr := range o.Attributes {
+		names[i] = attr.Name
+	}
+	return names
+}
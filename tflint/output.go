@@ -0,0 +1,55 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// Output is an `output` block declared in the module.
+// Each attribute is nil if not declared.
+type Output struct {
+	Name      string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the output block.
+	Ranges BlockRanges
+
+	Value       *hcl.Attribute
+	Description *hcl.Attribute
+	Sensitive   *hcl.Attribute
+	DependsOn   *hcl.Attribute
+}
+
+// outputSchema is the schema of output blocks
+var outputSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value"},
+		{Name: "description"},
+		{Name: "sensitive"},
+		{Name: "depends_on"},
+	},
+}
+
+// NewOutput extracts the output declaration from the passed output block.
+func NewOutput(output *hcl.Block) (*Output, hcl.Diagnostics) {
+	content, _, diags := output.Body.PartialContent(outputSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Output{
+		Name:        output.Labels[0],
+		DeclRange:   output.DefRange,
+		Ranges:      NewBlockRanges(output),
+		Value:       content.Attributes["value"],
+		Description: content.Attributes["description"],
+		Sensitive:   content.Attributes["sensitive"],
+		DependsOn:   content.Attributes["depends_on"],
+	}, nil
+}
+
+// DescriptionText returns the description. An empty string is returned if it is not declared or not a static string.
+func (o *Output) DescriptionText() string {
+	return staticString(o.Description)
+}
+
+// IsSensitive returns true if the output is declared as sensitive.
+func (o *Output) IsSensitive() bool {
+	return staticBool(o.Sensitive)
+}
@@ -0,0 +1,52 @@
+package tflint
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Filenames in ranges transferred via RPC are slash-separated paths relative to the module root,
+// so that plugins can compare them regardless of the host OS (e.g. Windows host and WSL plugins).
+// Hosts should normalize filenames with NormalizePath before parsing files,
+// and plugins can resolve them back to host paths with ResolvePath if needed.
+// The Client also normalizes filenames in walk responses and annotations, so rules get consistent paths even from hosts that do not.
+
+// NormalizePath returns the passed path as a slash-separated path relative to the root.
+// If the path is outside of the root, it returns the absolute path in slash form, so that ResolvePath can restore it.
+func NormalizePath(root, path string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// ResolvePath returns the host path of the passed normalized path.
+// The root is usually RunMetadata.ModuleRoot.
+func ResolvePath(root, path string) string {
+	path = filepath.FromSlash(path)
+	if filepath.IsAbs(path) || root == "" {
+		return path
+	}
+	return filepath.Join(root, path)
+}
+
+// normalizeFilename is the same as NormalizePath, except that it handles both slash and backslash separators
+// regardless of the OS of the plugin, because the host may run on another OS. It is used by the Client for received ranges.
+func normalizeFilename(root, filename string) string {
+	if filename == "" || strings.HasPrefix(filename, filenameRefPrefix) {
+		return filename
+	}
+	filename = strings.ReplaceAll(filename, `\`, "/")
+	if root != "" {
+		root = strings.TrimSuffix(strings.ReplaceAll(root, `\`, "/"), "/")
+		if strings.HasPrefix(filename, root+"/") {
+			filename = filename[len(root)+1:]
+		}
+	}
+	return filename
+}
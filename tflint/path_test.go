@@ -0,0 +1,98 @@
+package tflint
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_NormalizePath(t *testing.T) {
+	root, err := filepath.Abs(filepath.Join("work", "module"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(filepath.Dir(root), "other", "main.tf")
+
+	cases := []struct {
+		Name     string
+		Path     string
+		Expected string
+	}{
+		{
+			Name:     "file in root",
+			Path:     filepath.Join(root, "main.tf"),
+			Expected: "main.tf",
+		},
+		{
+			Name:     "file in sub directory",
+			Path:     filepath.Join(root, "modules", "vpc", "main.tf"),
+			Expected: "modules/vpc/main.tf",
+		},
+		{
+			Name:     "file outside of root",
+			Path:     outside,
+			Expected: filepath.ToSlash(outside),
+		},
+	}
+
+	for _, tc := range cases {
+		got := NormalizePath(root, tc.Path)
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected %s, but got %s", tc.Name, tc.Expected, got)
+		}
+
+		if resolved := ResolvePath(root, got); resolved != tc.Path {
+			t.Fatalf("Failed `%s` test: expected resolved path is %s, but got %s", tc.Name, tc.Path, resolved)
+		}
+	}
+}
+
+func Test_normalizeFilename(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Root     string
+		Filename string
+		Expected string
+	}{
+		{
+			Name:     "normalized",
+			Root:     "/work/module",
+			Filename: "modules/vpc/main.tf",
+			Expected: "modules/vpc/main.tf",
+		},
+		{
+			Name:     "absolute path in root",
+			Root:     "/work/module",
+			Filename: "/work/module/modules/vpc/main.tf",
+			Expected: "modules/vpc/main.tf",
+		},
+		{
+			Name:     "Windows path in root",
+			Root:     `C:\work\module\`,
+			Filename: `C:\work\module\modules\vpc\main.tf`,
+			Expected: "modules/vpc/main.tf",
+		},
+		{
+			Name:     "path outside of root",
+			Root:     "/work/module",
+			Filename: "/work/module2/main.tf",
+			Expected: "/work/module2/main.tf",
+		},
+		{
+			Name:     "unknown root",
+			Filename: `modules\vpc\main.tf`,
+			Expected: "modules/vpc/main.tf",
+		},
+		{
+			Name:     "reference to filename table",
+			Root:     "/work/module",
+			Filename: filenameRefPrefix + "0",
+			Expected: filenameRefPrefix + "0",
+		},
+	}
+
+	for _, tc := range cases {
+		if got := normalizeFilename(tc.Root, tc.Filename); got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected %s, but got %s", tc.Name, tc.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package tflint
+
+import (
+	"sync"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// attributesResponsePool keeps AttributesResponse to reuse attribute structs between walks.
+// gob reuses the capacity of a slice and non-nil pointers when decoding, so the allocations
+// per walk are reduced significantly on large configurations.
+var attributesResponsePool = sync.Pool{
+	New: func() interface{} { return &AttributesResponse{} },
+}
+
+// getAttributesResponse returns a zeroed AttributesResponse from the pool.
+// Since gob omits zero values in the stream, stale fields must be cleared before decoding.
+func getAttributesResponse() *AttributesResponse {
+	resp := attributesResponsePool.Get().(*AttributesResponse)
+
+	attributes := resp.Attributes[:cap(resp.Attributes)]
+	for _, attribute := range attributes {
+		if attribute != nil {
+			*attribute = hcl.Attribute{}
+		}
+	}
+	resp.Attributes = attributes[:0]
+	resp.Filenames = nil
+	resp.Err = nil
+
+	return resp
+}
+
+// putAttributesResponse returns the passed response to the pool.
+// Attributes in the response must not be referenced after this.
+func putAttributesResponse(resp *AttributesResponse) {
+	attributesResponsePool.Put(resp)
+}
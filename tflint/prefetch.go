@@ -0,0 +1,99 @@
+package tflint
+
+// DataRequirement is a piece of data that a rule needs from the host.
+// Either AttributeName or BlockType should be set.
+type DataRequirement struct {
+	// Resource is the resource type. It can be a glob pattern like WalkResourceAttributes.
+	Resource string
+	// AttributeName is the name of attributes walked by WalkResourceAttributes.
+	AttributeName string
+	// BlockType is the type of nested blocks walked by WalkResourceBlocks.
+	BlockType string
+}
+
+// DataRequirer is an optional interface for rules to declare their data needs statically.
+// Requirements of all rules are aggregated and fetched from the host in a single request before rules are checked,
+// and rules receive the prefetched results from the walkers without RPC calls.
+//
+// Example:
+//
+//	func (r *AwsInstanceInvalidTypeRule) DataRequirements() []tflint.DataRequirement {
+//		return []tflint.DataRequirement{{Resource: "aws_instance", AttributeName: "instance_type"}}
+//	}
+type DataRequirer interface {
+	DataRequirements() []DataRequirement
+}
+
+// PrefetchRequest is the interface used to communicate via RPC.
+type PrefetchRequest struct {
+	Attributes []AttributesRequest
+	Blocks     []BlocksRequest
+}
+
+// PrefetchResponse is the interface used to communicate via RPC.
+// Responses are in the same order as requests.
+type PrefetchResponse struct {
+	Attributes []*AttributesResponse
+	Blocks     []*BlocksResponse
+	Err        error
+}
+
+// newPrefetchRequest aggregates requirements of the passed rules into a request without duplicates.
+// It returns nil if no rules declare requirements.
+func newPrefetchRequest(rules []Rule) *PrefetchRequest {
+	var req *PrefetchRequest
+	seen := map[DataRequirement]bool{}
+
+	for _, rule := range rules {
+		requirer, ok := rule.(DataRequirer)
+		if !ok {
+			continue
+		}
+		if req == nil {
+			req = &PrefetchRequest{}
+		}
+
+		for _, requirement := range requirer.DataRequirements() {
+			if seen[requirement] {
+				continue
+			}
+			seen[requirement] = true
+
+			if requirement.AttributeName != "" {
+				req.Attributes = append(req.Attributes, AttributesRequest{Resource: requirement.Resource, AttributeName: requirement.AttributeName, CompactFilenames: true})
+			}
+			if requirement.BlockType != "" {
+				req.Blocks = append(req.Blocks, BlocksRequest{Resource: requirement.Resource, BlockType: requirement.BlockType, CompactFilenames: true})
+			}
+		}
+	}
+	return req
+}
+
+// prefetch queries the host process for the passed request in a single call, and stores the results as shared responses,
+// so that walkers return them without RPC calls. Sharing must be enabled.
+// Failures are not fatal because walkers fall back to querying the host (e.g. hosts that do not support prefetching).
+func (c *Client) prefetch(req *PrefetchRequest) {
+	c.logger.Printf("[DEBUG] Prefetch %d attributes and %d blocks", len(req.Attributes), len(req.Blocks))
+
+	var response PrefetchResponse
+	if err := c.rpcCall("Plugin.Prefetch", req, &response); err != nil {
+		c.logger.Printf("[WARN] Failed to prefetch: %s", err)
+		return
+	}
+	if response.Err != nil {
+		c.logger.Printf("[WARN] Failed to prefetch: %s", response.Err)
+		return
+	}
+	if len(response.Attributes) != len(req.Attributes) || len(response.Blocks) != len(req.Blocks) {
+		c.logger.Printf("[WARN] Failed to prefetch: the number of responses does not match the number of requests")
+		return
+	}
+
+	for i, attributes := range req.Attributes {
+		c.share(sharedKey("Plugin.Attributes", attributes), response.Attributes[i])
+	}
+	for i, blocks := range req.Blocks {
+		c.share(sharedKey("Plugin.Blocks", blocks), response.Blocks[i])
+	}
+}
@@ -0,0 +1,79 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+type requirerRule struct {
+	testRule
+	requirements []DataRequirement
+	check        func(Runner) error
+}
+
+func (r *requirerRule) DataRequirements() []DataRequirement { return r.requirements }
+func (r *requirerRule) Check(runner Runner) error           { return r.check(runner) }
+
+func Test_newPrefetchRequest(t *testing.T) {
+	if req := newPrefetchRequest([]Rule{&testRule{}}); req != nil {
+		t.Fatalf("Expected nil, but got %#v", req)
+	}
+
+	req := newPrefetchRequest([]Rule{
+		&requirerRule{requirements: []DataRequirement{
+			{Resource: "aws_instance", AttributeName: "instance_type"},
+			{Resource: "aws_instance", BlockType: "ebs_block_device"},
+		}},
+		&testRule{},
+		&requirerRule{requirements: []DataRequirement{
+			{Resource: "aws_instance", AttributeName: "instance_type"},
+			{Resource: "aws_*", AttributeName: "tags"},
+		}},
+	})
+	expected := &PrefetchRequest{
+		Attributes: []AttributesRequest{
+			{Resource: "aws_instance", AttributeName: "instance_type", CompactFilenames: true},
+			{Resource: "aws_*", AttributeName: "tags", CompactFilenames: true},
+		},
+		Blocks: []BlocksRequest{
+			{Resource: "aws_instance", BlockType: "ebs_block_device", CompactFilenames: true},
+		},
+	}
+	if !cmp.Equal(expected, req) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, req))
+	}
+}
+
+func Test_Check_prefetch(t *testing.T) {
+	client, server := startMockServer(t)
+	defer server.Listener.Close()
+
+	walk := func(runner Runner) error {
+		filenames := []string{}
+		err := runner.WalkResourceAttributes("aws_instance", "instance_type", func(attribute *hcl.Attribute) error {
+			filenames = append(filenames, attribute.Range.Filename)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(filenames) != 1 || filenames[0] != "prefetched.tf" {
+			t.Fatalf("Expected prefetched attributes, but got %#v", filenames)
+		}
+		if runner.(*Client).calls != 0 {
+			t.Fatalf("Expected no RPC calls, but got %d", runner.(*Client).calls)
+		}
+		return nil
+	}
+	requirements := []DataRequirement{{Resource: "aws_instance", AttributeName: "instance_type"}}
+
+	ruleset := &RuleSet{Rules: []Rule{
+		&requirerRule{requirements: requirements, check: walk},
+		&requirerRule{requirements: requirements, check: walk},
+	}}
+	if err := ruleset.Check(client); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
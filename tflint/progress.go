@@ -0,0 +1,54 @@
+package tflint
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress tracks the rule being checked.
+// It is safe for concurrent use, so it can be monitored from other goroutines while checking.
+type Progress struct {
+	mu      sync.Mutex
+	rule    string
+	started time.Time
+}
+
+// Current returns the name of the rule being checked and the time it started.
+// The name is empty if no rule is being checked.
+func (p *Progress) Current() (string, time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rule, p.started
+}
+
+func (p *Progress) start(rule string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rule = rule
+	p.started = time.Now()
+}
+
+func (p *Progress) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rule = ""
+	p.started = time.Time{}
+}
+
+// ProgressUpdate is a progress report sent by a rule that takes a long time to check.
+// Hosts can show it as a status line so that interactive runs don't appear hung.
+type ProgressUpdate struct {
+	Rule string
+	// Percent is the completion from 0 to 100. It is negative if the completion cannot be measured.
+	Percent int
+	Message string
+}
+
+// TrackProgress enables progress tracking and returns the Progress.
+// The ruleset shares the Progress with its copies, so call this before passing the ruleset to other components.
+func (r *RuleSet) TrackProgress() *Progress {
+	if r.progress == nil {
+		r.progress = &Progress{}
+	}
+	return r.progress
+}
@@ -0,0 +1,70 @@
+package tflint
+
+import (
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// AttributeFields is a set of fields of attributes transferred in walk responses (projection).
+// Rules that only check the presence or location of attributes can omit expressions to trim payloads.
+// Name and Range are always transferred because they identify attributes and are needed to apply annotations.
+type AttributeFields int
+
+const (
+	// AttributeRange selects Name and Range.
+	AttributeRange AttributeFields = 1 << iota
+	// AttributeNameRange selects NameRange.
+	AttributeNameRange
+	// AttributeExpr selects Expr.
+	AttributeExpr
+
+	// AllAttributeFields selects all fields. The zero value is treated as the same.
+	AllAttributeFields = AttributeRange | AttributeNameRange | AttributeExpr
+)
+
+// Has returns true if all the passed fields are selected.
+func (f AttributeFields) Has(fields AttributeFields) bool {
+	if f == 0 {
+		f = AllAttributeFields
+	}
+	return f&fields == fields
+}
+
+func (f AttributeFields) String() string {
+	if f.Has(AllAttributeFields) {
+		return "all"
+	}
+
+	names := []string{}
+	if f.Has(AttributeRange) {
+		names = append(names, "range")
+	}
+	if f.Has(AttributeNameRange) {
+		names = append(names, "name_range")
+	}
+	if f.Has(AttributeExpr) {
+		names = append(names, "expr")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ",")
+}
+
+// ProjectAttribute returns the attribute with only the selected fields. Unselected fields are zero values.
+// The attribute is returned as is if all fields are selected, otherwise a copy is returned.
+func ProjectAttribute(attribute *hcl.Attribute, fields AttributeFields) *hcl.Attribute {
+	if attribute == nil || fields.Has(AllAttributeFields) {
+		return attribute
+	}
+
+	projected := &hcl.Attribute{Name: attribute.Name, Range: attribute.Range}
+	if fields.Has(AttributeNameRange) {
+		projected.NameRange = attribute.NameRange
+	}
+	if fields.Has(AttributeExpr) {
+		projected.Expr = attribute.Expr
+	}
+	return projected
+}
@@ -0,0 +1,53 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_ProjectAttribute(t *testing.T) {
+	attribute := &hcl.Attribute{
+		Name:      "tags",
+		Expr:      hcl.StaticExpr(cty.StringVal("web"), hcl.Range{Filename: "main.tf"}),
+		Range:     hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2}},
+		NameRange: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2, Column: 3}},
+	}
+
+	if got := ProjectAttribute(attribute, 0); got != attribute {
+		t.Fatal("Expected the same attribute for all fields")
+	}
+
+	got := ProjectAttribute(attribute, AttributeRange|AttributeNameRange)
+	expected := &hcl.Attribute{Name: "tags", Range: attribute.Range, NameRange: attribute.NameRange}
+	if !cmp.Equal(expected, got) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, got))
+	}
+
+	if sharedKey("Plugin.Attributes", AttributesRequest{Resource: "aws_instance", AttributeName: "tags", Fields: AttributeRange}) == sharedKey("Plugin.Attributes", AttributesRequest{Resource: "aws_instance", AttributeName: "tags"}) {
+		t.Fatal("Expected projected responses not to be shared with full responses")
+	}
+}
+
+func Test_AttributeFields_String(t *testing.T) {
+	cases := []struct {
+		Fields   AttributeFields
+		Expected string
+	}{
+		{Fields: 0, Expected: "all"},
+		{Fields: AllAttributeFields, Expected: "all"},
+		{Fields: AttributeRange, Expected: "range"},
+		{Fields: AttributeRange | AttributeExpr, Expected: "range,expr"},
+		{Fields: AttributeNameRange, Expected: "name_range"},
+		{Fields: AttributeExpr, Expected: "expr"},
+		{Fields: 1 << 8, Expected: "none"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.Fields.String(); got != tc.Expected {
+			t.Fatalf("Expected `%s` for %d, but got `%s`", tc.Expected, tc.Fields, got)
+		}
+	}
+}
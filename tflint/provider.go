@@ -0,0 +1,52 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ProviderConfig is a `provider` block declared in the module.
+type ProviderConfig struct {
+	Name string
+	// Alias is the value of the `alias` attribute. It is empty for the default configuration.
+	Alias     string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the provider block.
+	Ranges BlockRanges
+	// Attributes is the set of attributes keyed by name, including `alias`.
+	// Nested blocks (e.g. `assume_role`) are not included in native syntax.
+	Attributes map[string]*hcl.Attribute
+}
+
+// Key returns the name with the alias (e.g. "aws.west"), or only the name for the default configuration.
+func (p *ProviderConfig) Key() string {
+	if p.Alias == "" {
+		return p.Name
+	}
+	return p.Name + "." + p.Alias
+}
+
+// NewProviderConfig extracts the provider configuration from the passed provider block.
+func NewProviderConfig(provider *hcl.Block) (*ProviderConfig, hcl.Diagnostics) {
+	attributes, diags := bodyAttributes(provider.Body)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	config := &ProviderConfig{
+		Name:       provider.Labels[0],
+		DeclRange:  provider.DefRange,
+		Ranges:     NewBlockRanges(provider),
+		Attributes: attributes,
+	}
+	if alias, exists := attributes["alias"]; exists {
+		val, diags := alias.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if val.IsKnown() && !val.IsNull() && val.Type() == cty.String {
+			config.Alias = val.AsString()
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,126 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Provisioner is a `provisioner` block declared in a resource.
+type Provisioner struct {
+	// Type is the provisioner type (e.g. "local-exec", "remote-exec", "file").
+	Type string
+	// ResourceType and ResourceName are the labels of the resource containing the provisioner.
+	ResourceType string
+	ResourceName string
+	DeclRange    hcl.Range
+	// Ranges is the set of ranges of the provisioner block.
+	Ranges BlockRanges
+	// Attributes is the set of attributes keyed by name, including `when` and `on_failure`.
+	Attributes map[string]*hcl.Attribute
+	// Connection is the `connection` block that applies to the provisioner. It is the block declared in the provisioner
+	// if any, otherwise the block declared in the resource. It is nil if neither is declared.
+	Connection *Connection
+}
+
+// Connection is a `connection` block declared in a resource or a provisioner.
+type Connection struct {
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the connection block.
+	Ranges BlockRanges
+	// Attributes is the set of attributes keyed by name (e.g. type, host, user).
+	Attributes map[string]*hcl.Attribute
+}
+
+// provisionerSchema is the schema of provisioners and connections in resources
+var provisionerSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "provisioner", LabelNames: []string{"type"}},
+		{Type: "connection"},
+	},
+}
+
+// connectionSchema is the schema of connections in provisioners
+var connectionSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "connection"},
+	},
+}
+
+// NewProvisioners extracts provisioners from the passed resource block in declaration order.
+func NewProvisioners(resource *hcl.Block) ([]*Provisioner, hcl.Diagnostics) {
+	content, _, diags := resource.Body.PartialContent(provisionerSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var connection *Connection
+	for _, block := range content.Blocks {
+		if block.Type == "connection" && connection == nil {
+			connection, diags = newConnection(block)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+		}
+	}
+
+	provisioners := []*Provisioner{}
+	for _, block := range content.Blocks {
+		if block.Type != "provisioner" {
+			continue
+		}
+
+		content, remain, diags := block.Body.PartialContent(connectionSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		attributes, diags := bodyAttributes(remain)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		provisioner := &Provisioner{
+			Type:         block.Labels[0],
+			ResourceType: resource.Labels[0],
+			ResourceName: resource.Labels[1],
+			DeclRange:    block.DefRange,
+			Ranges:       NewBlockRanges(block),
+			Attributes:   attributes,
+			Connection:   connection,
+		}
+		if len(content.Blocks) > 0 {
+			provisioner.Connection, diags = newConnection(content.Blocks[0])
+			if diags.HasErrors() {
+				return nil, diags
+			}
+		}
+		provisioners = append(provisioners, provisioner)
+	}
+	return provisioners, nil
+}
+
+func newConnection(block *hcl.Block) (*Connection, hcl.Diagnostics) {
+	attributes, diags := bodyAttributes(block.Body)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return &Connection{
+		DeclRange:  block.DefRange,
+		Ranges:     NewBlockRanges(block),
+		Attributes: attributes,
+	}, nil
+}
+
+// bodyAttributes returns attributes in the passed body.
+// Native syntax bodies can contain nested blocks, which JustAttributes rejects, so nested blocks are ignored in native syntax.
+func bodyAttributes(body hcl.Body) (hcl.Attributes, hcl.Diagnostics) {
+	native, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return body.JustAttributes()
+	}
+
+	attributes := hcl.Attributes{}
+	for name, attribute := range native.Attributes {
+		attributes[name] = attribute.AsHCLAttribute()
+	}
+	return attributes, nil
+}
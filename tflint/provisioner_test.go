@@ -0,0 +1,96 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+)
+
+func Test_NewProvisioners(t *testing.T) {
+	cases := []struct {
+		Name     string
+		File     func() (*hcl.File, hcl.Diagnostics)
+		Expected []string
+	}{
+		{
+			Name: "native syntax",
+			File: func() (*hcl.File, hcl.Diagnostics) {
+				return hclsyntax.ParseConfig([]byte(`
+resource "aws_instance" "web" {
+  connection {
+    host = self.public_ip
+  }
+
+  provisioner "local-exec" {
+    command = "echo ${self.private_ip}"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["puppet apply"]
+
+    connection {
+      host = self.private_ip
+    }
+  }
+}`), "main.tf", hcl.InitialPos)
+			},
+			Expected: []string{
+				"local-exec", "aws_instance.web", "command", "main.tf:4,5-26",
+				"remote-exec", "aws_instance.web", "inline", "main.tf:15,7-29",
+			},
+		},
+		{
+			Name: "JSON syntax",
+			File: func() (*hcl.File, hcl.Diagnostics) {
+				return json.Parse([]byte(`{
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "provisioner": {
+          "local-exec": {
+            "command": "echo",
+            "connection": { "host": "10.0.0.1" }
+          }
+        }
+      }
+    }
+  }
+}`), "main.tf.json")
+			},
+			Expected: []string{"local-exec", "aws_instance.web", "command", "main.tf.json:8,29-47"},
+		},
+	}
+
+	for _, tc := range cases {
+		file, diags := tc.File()
+		if diags.HasErrors() {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, diags)
+		}
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+		})
+		if diags.HasErrors() {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, diags)
+		}
+
+		provisioners, diags := NewProvisioners(content.Blocks[0])
+		if diags.HasErrors() {
+			t.Fatalf("Failed `%s` test: unexpected error occurred: %s", tc.Name, diags)
+		}
+
+		got := []string{}
+		for _, provisioner := range provisioners {
+			got = append(got, provisioner.Type, provisioner.ResourceType+"."+provisioner.ResourceName)
+			for name := range provisioner.Attributes {
+				got = append(got, name)
+			}
+			got = append(got, provisioner.Connection.Attributes["host"].Range.String())
+		}
+		if !cmp.Equal(tc.Expected, got) {
+			t.Fatalf("Failed `%s` test: diff: %s", tc.Name, cmp.Diff(tc.Expected, got))
+		}
+	}
+}
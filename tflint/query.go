@@ -0,0 +1,21 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// QueryRule is an optional interface for rules that return data to the host in query mode (e.g. `tflint --query`),
+// such as an inventory of AMIs in use. In query mode, only enabled rules that implement it are run,
+// and Query is called instead of Check. Query rules report data with Runner.EmitRecord.
+type QueryRule interface {
+	Query(Runner) error
+}
+
+// Record is a piece of data emitted by a rule in query mode.
+// Value can be any value (e.g. an object of fields), so hosts can output records as structured data such as JSON.
+type Record struct {
+	Rule     string
+	Value    cty.Value
+	Location hcl.Range
+}
@@ -0,0 +1,29 @@
+package tflint
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// RedactedValue is the replacement for sensitive values in issue messages.
+const RedactedValue = "***"
+
+// FormatValue formats the value evaluated from the passed expression for issue messages.
+// If the value is sensitive, it returns RedactedValue instead so that secrets are not leaked into CI logs.
+// It also returns RedactedValue if the sensitivity cannot be determined.
+//
+// Example:
+//
+//	runner.EmitIssue(r, fmt.Sprintf("%s is an invalid name", tflint.FormatValue(runner, attr.Expr, name)), attr.Expr.Range(), tflint.Metadata{Expr: attr.Expr})
+func FormatValue(runner Runner, expr hcl.Expression, val interface{}) string {
+	sensitive, err := runner.IsSensitive(expr)
+	if err != nil || sensitive {
+		return RedactedValue
+	}
+
+	if str, ok := val.(string); ok {
+		return fmt.Sprintf(`"%s"`, str)
+	}
+	return fmt.Sprintf("%v", val)
+}
@@ -0,0 +1,82 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// Moved is a `moved` block declared in the module.
+type Moved struct {
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the moved block.
+	Ranges BlockRanges
+
+	// From and To are references to the old and new addresses (e.g. `aws_instance.old`). They are nil if not declared.
+	From *hcl.Attribute
+	To   *hcl.Attribute
+}
+
+// movedSchema is the schema of moved blocks
+var movedSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "from"},
+		{Name: "to"},
+	},
+}
+
+// NewMoved extracts the moved declaration from the passed moved block.
+func NewMoved(moved *hcl.Block) (*Moved, hcl.Diagnostics) {
+	content, _, diags := moved.Body.PartialContent(movedSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Moved{
+		DeclRange: moved.DefRange,
+		Ranges:    NewBlockRanges(moved),
+		From:      content.Attributes["from"],
+		To:        content.Attributes["to"],
+	}, nil
+}
+
+// Import is an `import` block declared in the module.
+// Each attribute is nil if not declared.
+type Import struct {
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the import block.
+	Ranges BlockRanges
+
+	To       *hcl.Attribute
+	ID       *hcl.Attribute
+	Provider *hcl.Attribute
+	ForEach  *hcl.Attribute
+}
+
+// importSchema is the schema of import blocks
+var importSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "to"},
+		{Name: "id"},
+		{Name: "provider"},
+		{Name: "for_each"},
+	},
+}
+
+// NewImport extracts the import declaration from the passed import block.
+func NewImport(imp *hcl.Block) (*Import, hcl.Diagnostics) {
+	content, _, diags := imp.Body.PartialContent(importSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Import{
+		DeclRange: imp.DefRange,
+		Ranges:    NewBlockRanges(imp),
+		To:        content.Attributes["to"],
+		ID:        content.Attributes["id"],
+		Provider:  content.Attributes["provider"],
+		ForEach:   content.Attributes["for_each"],
+	}, nil
+}
+
+// IDText returns the import ID. An empty string is returned if it is not declared or not a static string.
+func (i *Import) IDText() string {
+	return staticString(i.ID)
+}
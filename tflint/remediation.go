@@ -0,0 +1,85 @@
+package tflint
+
+import (
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+const (
+	// SetAttributeRemediation sets the attribute to the value
+	SetAttributeRemediation = "set_attribute"
+	// RemoveAttributeRemediation removes the attribute
+	RemoveAttributeRemediation = "remove_attribute"
+	// AddBlockRemediation adds the block
+	AddBlockRemediation = "add_block"
+	// RemoveBlockRemediation removes the block
+	RemoveBlockRemediation = "remove_block"
+)
+
+// Remediation is a machine-readable hint on how to resolve an issue.
+// Unlike Fix, it does not need to be computed as text edits, so IDE integrations can turn it
+// into a quick-fix action even when a rule cannot build the exact edits.
+type Remediation struct {
+	Kind string
+	// Block is the range of the block to which the remediation is applied.
+	Block hcl.Range
+	// Name is the attribute name or the block type.
+	Name string
+	// Labels is the labels of the block to be added or removed.
+	Labels []string
+	// Value is the HCL source of the value to be set.
+	Value string
+	// Description is the human-readable description. If empty, Title generates it from the other fields.
+	Description string
+}
+
+// RemediateSetAttribute returns a remediation that sets the attribute in the block to the value.
+// The value is HCL source text such as `"t2.micro"` or `true`.
+func RemediateSetAttribute(block hcl.Range, name string, value string) Remediation {
+	return Remediation{Kind: SetAttributeRemediation, Block: block, Name: name, Value: value}
+}
+
+// RemediateRemoveAttribute returns a remediation that removes the attribute from the block.
+func RemediateRemoveAttribute(block hcl.Range, name string) Remediation {
+	return Remediation{Kind: RemoveAttributeRemediation, Block: block, Name: name}
+}
+
+// RemediateAddBlock returns a remediation that adds a nested block to the block.
+func RemediateAddBlock(block hcl.Range, blockType string, labels ...string) Remediation {
+	return Remediation{Kind: AddBlockRemediation, Block: block, Name: blockType, Labels: labels}
+}
+
+// RemediateRemoveBlock returns a remediation that removes the nested block from the block.
+func RemediateRemoveBlock(block hcl.Range, blockType string, labels ...string) Remediation {
+	return Remediation{Kind: RemoveBlockRemediation, Block: block, Name: blockType, Labels: labels}
+}
+
+// Title returns the description used as the title of quick-fix actions.
+func (r Remediation) Title() string {
+	if r.Description != "" {
+		return r.Description
+	}
+
+	switch r.Kind {
+	case SetAttributeRemediation:
+		return fmt.Sprintf("Set `%s` to `%s`", r.Name, r.Value)
+	case RemoveAttributeRemediation:
+		return fmt.Sprintf("Remove `%s`", r.Name)
+	case AddBlockRemediation:
+		return fmt.Sprintf("Add `%s` block", r.blockName())
+	case RemoveBlockRemediation:
+		return fmt.Sprintf("Remove `%s` block", r.blockName())
+	default:
+		return r.Kind
+	}
+}
+
+func (r Remediation) blockName() string {
+	parts := []string{r.Name}
+	for _, label := range r.Labels {
+		parts = append(parts, fmt.Sprintf(`"%s"`, label))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,50 @@
+package tflint
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+func Test_Remediation_Title(t *testing.T) {
+	block := hcl.Range{Filename: "main.tf"}
+
+	cases := []struct {
+		Name        string
+		Remediation Remediation
+		Expected    string
+	}{
+		{
+			Name:        "set attribute",
+			Remediation: RemediateSetAttribute(block, "instance_type", `"t2.micro"`),
+			Expected:    "Set `instance_type` to `\"t2.micro\"`",
+		},
+		{
+			Name:        "remove attribute",
+			Remediation: RemediateRemoveAttribute(block, "ami"),
+			Expected:    "Remove `ami`",
+		},
+		{
+			Name:        "add block",
+			Remediation: RemediateAddBlock(block, "lifecycle"),
+			Expected:    "Add `lifecycle` block",
+		},
+		{
+			Name:        "remove block with labels",
+			Remediation: RemediateRemoveBlock(block, "provisioner", "local-exec"),
+			Expected:    "Remove `provisioner \"local-exec\"` block",
+		},
+		{
+			Name:        "description",
+			Remediation: Remediation{Kind: AddBlockRemediation, Name: "tags", Description: "Add tags"},
+			Expected:    "Add tags",
+		},
+	}
+
+	for _, tc := range cases {
+		got := tc.Remediation.Title()
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected `%s`, but got `%s`", tc.Name, tc.Expected, got)
+		}
+	}
+}
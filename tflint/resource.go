@@ -0,0 +1,110 @@
+package tflint
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ResourceAttributeNames is the set of attribute names present in a resource.
+type ResourceAttributeNames struct {
+	Type      string
+	Name      string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the resource block.
+	Ranges BlockRanges
+	// Attributes is a list of attribute names sorted alphabetically.
+	Attributes []string
+}
+
+// Has returns true if the resource has the passed attribute.
+func (r *ResourceAttributeNames) Has(name string) bool {
+	for _, attribute := range r.Attributes {
+		if attribute == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourcesWithoutAttribute returns the resources of the passed type that do not declare the passed attribute.
+// Attribute walkers never invoke the walker when the attribute is absent, so use it for required attributes.
+// Only attributes are taken into account. Use WalkResources if you need to check nested blocks as well.
+//
+// Example:
+//
+//	resources, err := tflint.ResourcesWithoutAttribute(runner, "aws_instance", "tags")
+//	if err != nil {
+//		return err
+//	}
+//	for _, resource := range resources {
+//		if err := runner.EmitIssue(rule, "tags is required", resource.DeclRange, tflint.Metadata{}); err != nil {
+//			return err
+//		}
+//	}
+func ResourcesWithoutAttribute(runner Runner, resourceType string, name string) ([]*ResourceAttributeNames, error) {
+	resources, err := runner.GetResourceAttributeNames(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*ResourceAttributeNames{}
+	for _, resource := range resources {
+		if !resource.Has(name) {
+			ret = append(ret, resource)
+		}
+	}
+	return ret, nil
+}
+
+// Resource is a resource declared in the module with its whole body.
+// Rules can decode the body themselves, e.g. to find missing attributes.
+type Resource struct {
+	Type string
+	Name string
+	// Labels is the labels of the resource block (e.g. ["aws_instance", "web"]).
+	Labels    []string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the resource block.
+	Ranges BlockRanges
+	// Body is the body of the resource block. Decode it with the Content or PartialContent method.
+	Body hcl.Body
+}
+
+// NewResource returns the resource of the passed resource block.
+func NewResource(resource *hcl.Block) *Resource {
+	return &Resource{
+		Type:      resource.Labels[0],
+		Name:      resource.Labels[1],
+		Labels:    resource.Labels,
+		DeclRange: resource.DefRange,
+		Ranges:    NewBlockRanges(resource),
+		Body:      resource.Body,
+	}
+}
+
+// parseBody parses the passed source of the resource block in native syntax and sets the body.
+// Bodies cannot always be sent via RPC as they are (e.g. expressions with operators), so hosts send the source instead.
+func (r *Resource) parseBody(src []byte) hcl.Diagnostics {
+	file, diags := hclsyntax.ParseConfig(src, r.DeclRange.Filename, r.Ranges.TypeRange.Start)
+	if diags.HasErrors() {
+		return diags
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+	if len(content.Blocks) != 1 {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid resource source",
+			Detail:   fmt.Sprintf("The source of `%s.%s` must contain exactly one resource block", r.Type, r.Name),
+			Subject:  &r.DeclRange,
+		}}
+	}
+	r.Body = content.Blocks[0].Body
+	return nil
+}
@@ -1,12 +1,46 @@
 package tflint
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty/function"
+)
 
 // RuleSet is a list of rules that a plugin should provide
 type RuleSet struct {
 	Name    string
 	Version string
+	// DocsURL is a template of rule documentation links.
+	// `{{version}}` is replaced with the ruleset version, and `{{slug}}` is replaced with the rule slug.
+	// If set, it is used to compute links of rules that return an empty Link().
+	DocsURL string
 	Rules   []Rule
+	// CallBudget is the maximum number of RPC calls that each rule can make. 0 means no limit.
+	// This protects users from rules that query the host too many times (e.g. in nested loops).
+	CallBudget int
+	// Messages is the catalog of message templates used for localizable issue messages. Optional.
+	Messages MessageCatalog
+	// ShareResults enables sharing results of resource walks (attributes, attribute values, data source attributes
+	// and nested blocks) among rules in a run. Each result is fetched from the host only once, and rules that walk
+	// the same resource type and attribute reuse it without RPC calls. Reused results are not counted against CallBudget.
+	// Each walk receives copies of attributes and blocks, so rules cannot affect each other by modifying them.
+	ShareResults bool
+	// Functions is a set of custom functions added to the evaluation context of the host, keyed by name.
+	// Hosts expose them namespaced with the ruleset name (see FunctionName), so that organizations can lint
+	// configurations relying on wrapper conventions, and rules can evaluate expressions using them.
+	Functions map[string]function.Function
+
+	offline  bool
+	query    bool
+	progress *Progress
+}
+
+// DocSlugger is an optional interface for rules to customize the slug used in DocsURL.
+// By default, the rule name is used as the slug.
+type DocSlugger interface {
+	DocSlug() string
 }
 
 // RuleSetName is the name of the rule set.
@@ -29,29 +63,173 @@ func (r *RuleSet) RuleNames() []string {
 	return names
 }
 
+// MessageCatalog returns the catalog of message templates provided by the plugin.
+func (r *RuleSet) MessageCatalog() MessageCatalog {
+	return r.Messages
+}
+
+// RuleLink returns the documentation link of the passed rule.
+// The rule's own Link() takes precedence over DocsURL.
+func (r *RuleSet) RuleLink(rule Rule) string {
+	if link := rule.Link(); link != "" || r.DocsURL == "" {
+		return link
+	}
+
+	slug := rule.Name()
+	if slugger, ok := rule.(DocSlugger); ok {
+		slug = slugger.DocSlug()
+	}
+
+	return strings.NewReplacer("{{version}}", r.Version, "{{slug}}", slug).Replace(r.DocsURL)
+}
+
+// Tagger is an optional interface for rules to declare tags (e.g. "security", "style").
+// Tags can be used by the host to select rules to run.
+type Tagger interface {
+	Tags() []string
+}
+
+// NetworkRule is an optional interface for rules that call external services (e.g. deep checking).
+// Rules that require the network are skipped in offline mode.
+type NetworkRule interface {
+	RequiresNetwork() bool
+}
+
+// DeduplicatedRule is an optional interface for rules that emit the same issue repeatedly
+// (e.g. loops over expanded instances). Identical issues (same rule, range and message) are emitted only once.
+type DeduplicatedRule interface {
+	DeduplicateIssues() bool
+}
+
+// DocumentedRule is an optional interface for rules to embed their documentation in Markdown.
+// It allows hosts to explain rules (e.g. `tflint --explain rule_name`) and docs sites to be generated from a single source.
+type DocumentedRule interface {
+	Documentation() string
+}
+
+// RuleDocumentation returns the Markdown documentation of the rule with the passed name.
+// An empty string is returned if the rule does not implement DocumentedRule.
+// Note that rules disabled by ApplyConfig are no longer found.
+func (r *RuleSet) RuleDocumentation(name string) (string, error) {
+	for _, rule := range r.Rules {
+		if rule.Name() != name {
+			continue
+		}
+		if documented, ok := rule.(DocumentedRule); ok {
+			return documented.Documentation(), nil
+		}
+		return "", nil
+	}
+	return "", fmt.Errorf("Rule not found: %s", name)
+}
+
+// ConfigurableRule is an optional interface for rules that accept rule-specific options.
+// ApplyConfig is called with the rule config if the rule is enabled and the config exists.
+type ConfigurableRule interface {
+	ApplyConfig(*RuleConfig) error
+}
+
 // ApplyConfig reflects the plugin configuration in the ruleset.
-// Currently used only to enable/disable rules.
-func (r *RuleSet) ApplyConfig(config *Config) {
+// Enabled rules are selected, and the rule config is applied to rules that implement ConfigurableRule.
+func (r *RuleSet) ApplyConfig(config *Config) error {
 	rules := []Rule{}
 	for _, rule := range r.Rules {
 		enabled := rule.Enabled()
 		if cfg := config.Rules[rule.Name()]; cfg != nil {
 			enabled = cfg.Enabled
 		}
+		if len(config.Only) > 0 {
+			enabled = contains(config.Only, rule.Name())
+		}
+		if len(config.Tags) > 0 && enabled {
+			enabled = false
+			if tagger, ok := rule.(Tagger); ok {
+				for _, tag := range tagger.Tags() {
+					if contains(config.Tags, tag) {
+						enabled = true
+						break
+					}
+				}
+			}
+		}
+		if config.Offline && enabled {
+			if network, ok := rule.(NetworkRule); ok && network.RequiresNetwork() {
+				enabled = false
+			}
+		}
+
+		if config.Query {
+			_, isQuery := rule.(QueryRule)
+			enabled = enabled && isQuery
+		}
 
-		if enabled {
-			rules = append(rules, rule)
+		if !enabled {
+			continue
 		}
+
+		if configurable, ok := rule.(ConfigurableRule); ok {
+			if cfg := config.Rules[rule.Name()]; cfg != nil {
+				if err := configurable.ApplyConfig(cfg); err != nil {
+					return fmt.Errorf("Failed to apply config to `%s` rule: %s", rule.Name(), err)
+				}
+			}
+		}
+		rules = append(rules, rule)
 	}
 	r.Rules = rules
+	r.offline = config.Offline
+	r.query = config.Query
+	return nil
 }
 
 // Check runs inspection for each rule by applying Runner.
+// In query mode, Query is called instead of Check.
+// Even if a rule fails, the remaining rules are checked, and all failures are returned as RuleErrors.
 func (r *RuleSet) Check(runner *Client) error {
+	runner.linker = r.RuleLink
+	runner.offline = r.offline
+	runner.budget = r.CallBudget
+	// Prefetched results are served as shared responses, so sharing is enabled if any rule declares requirements.
+	prefetch := newPrefetchRequest(r.Rules)
+	if r.ShareResults || prefetch != nil {
+		runner.shared = map[string]interface{}{}
+	}
+	if prefetch != nil {
+		runner.prefetch(prefetch)
+	}
+
+	errs := RuleErrors{}
 	for _, rule := range r.Rules {
-		if err := rule.Check(runner); err != nil {
-			return fmt.Errorf("Failed to check `%s` rule: %s", rule.Name(), err)
+		runner.rule = rule.Name()
+		runner.calls = 0
+		runner.ctx, runner.cancel = context.WithCancel(context.Background())
+		if r.progress != nil {
+			r.progress.start(rule.Name())
 		}
+		check := rule.Check
+		if query, ok := rule.(QueryRule); ok && r.query {
+			check = query.Query
+		}
+		if err := check(runner); err != nil {
+			errs = append(errs, RuleError{Rule: rule.Name(), Message: err.Error()})
+		}
+		runner.cancel()
+	}
+	if r.progress != nil {
+		r.progress.finish()
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package tflint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type slugRule struct {
+	testRule
+}
+
+func (*slugRule) DocSlug() string { return "custom" }
+
+type linkRule struct {
+	testRule
+}
+
+func (*linkRule) Link() string { return "https://example.com/own" }
+
+func Test_RuleLink(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DocsURL  string
+		Rule     Rule
+		Expected string
+	}{
+		{
+			Name:     "no docs URL",
+			Rule:     &testRule{},
+			Expected: "",
+		},
+		{
+			Name:     "rule name as slug",
+			DocsURL:  "https://example.com/blob/v{{version}}/docs/rules/{{slug}}.md",
+			Rule:     &testRule{},
+			Expected: "https://example.com/blob/v0.1.0/docs/rules/test.md",
+		},
+		{
+			Name:     "custom slug",
+			DocsURL:  "https://example.com/rules/{{slug}}",
+			Rule:     &slugRule{},
+			Expected: "https://example.com/rules/custom",
+		},
+		{
+			Name:     "rule's own link",
+			DocsURL:  "https://example.com/rules/{{slug}}",
+			Rule:     &linkRule{},
+			Expected: "https://example.com/own",
+		},
+	}
+
+	for _, tc := range cases {
+		ruleset := &RuleSet{Name: "test", Version: "0.1.0", DocsURL: tc.DocsURL}
+
+		got := ruleset.RuleLink(tc.Rule)
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected %s, but got %s", tc.Name, tc.Expected, got)
+		}
+	}
+}
+
+type namedRule struct {
+	testRule
+	name    string
+	enabled bool
+	tags    []string
+	network bool
+}
+
+func (r *namedRule) RequiresNetwork() bool { return r.network }
+
+func (r *namedRule) Name() string   { return r.name }
+func (r *namedRule) Enabled() bool  { return r.enabled }
+func (r *namedRule) Tags() []string { return r.tags }
+
+type queryRule struct {
+	namedRule
+}
+
+func (r *queryRule) Query(runner Runner) error { return errors.New("queried") }
+
+func Test_ApplyConfig(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   *Config
+		Expected []string
+	}{
+		{
+			Name:     "default",
+			Config:   &Config{Rules: map[string]*RuleConfig{}},
+			Expected: []string{"rule_a", "rule_b", "rule_d", "rule_f"},
+		},
+		{
+			Name: "rule config",
+			Config: &Config{Rules: map[string]*RuleConfig{
+				"rule_a": {Name: "rule_a", Enabled: false},
+				"rule_c": {Name: "rule_c", Enabled: true},
+			}},
+			Expected: []string{"rule_b", "rule_c", "rule_d", "rule_f"},
+		},
+		{
+			Name:     "only",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Only: []string{"rule_b", "rule_c"}},
+			Expected: []string{"rule_b", "rule_c"},
+		},
+		{
+			Name:     "tags",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Tags: []string{"security"}},
+			Expected: []string{"rule_a"},
+		},
+		{
+			Name:     "offline",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Offline: true},
+			Expected: []string{"rule_b", "rule_d"},
+		},
+		{
+			Name:     "query",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Query: true},
+			Expected: []string{"rule_d", "rule_f"},
+		},
+		{
+			Name: "query with rule config",
+			Config: &Config{Rules: map[string]*RuleConfig{
+				"rule_d": {Name: "rule_d", Enabled: false},
+				"rule_e": {Name: "rule_e", Enabled: true},
+			}, Query: true},
+			Expected: []string{"rule_e", "rule_f"},
+		},
+		{
+			Name:     "query with only",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Only: []string{"rule_b", "rule_e"}, Query: true},
+			Expected: []string{"rule_e"},
+		},
+		{
+			Name:     "query with tags",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Tags: []string{"inventory"}, Query: true},
+			Expected: []string{"rule_d"},
+		},
+		{
+			Name:     "query offline",
+			Config:   &Config{Rules: map[string]*RuleConfig{}, Offline: true, Query: true},
+			Expected: []string{"rule_d"},
+		},
+	}
+
+	for _, tc := range cases {
+		ruleset := &RuleSet{Rules: []Rule{
+			&namedRule{name: "rule_a", enabled: true, tags: []string{"security"}, network: true},
+			&namedRule{name: "rule_b", enabled: true, tags: []string{"style"}},
+			&namedRule{name: "rule_c", enabled: false, tags: []string{"security"}},
+			&queryRule{namedRule{name: "rule_d", enabled: true, tags: []string{"inventory"}}},
+			&queryRule{namedRule{name: "rule_e", enabled: false}},
+			&queryRule{namedRule{name: "rule_f", enabled: true, network: true}},
+		}}
+		if err := ruleset.ApplyConfig(tc.Config); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+
+		got := ruleset.RuleNames()
+		if !cmp.Equal(tc.Expected, got) {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, cmp.Diff(tc.Expected, got))
+		}
+	}
+}
+
+type documentedRule struct {
+	testRule
+}
+
+func (*documentedRule) Name() string          { return "documented" }
+func (*documentedRule) Documentation() string { return "# documented\n" }
+
+func Test_RuleDocumentation(t *testing.T) {
+	ruleset := &RuleSet{Rules: []Rule{&documentedRule{}, &testRule{}}}
+
+	doc, err := ruleset.RuleDocumentation("documented")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if doc != "# documented\n" {
+		t.Fatalf("Unexpected documentation: %s", doc)
+	}
+
+	doc, err = ruleset.RuleDocumentation("test")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if doc != "" {
+		t.Fatalf("Unexpected documentation: %s", doc)
+	}
+
+	_, err = ruleset.RuleDocumentation("unknown")
+	if err == nil || err.Error() != "Rule not found: unknown" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+type failedRule struct {
+	testRule
+	name string
+}
+
+func (r *failedRule) Name() string              { return r.name }
+func (r *failedRule) Check(runner Runner) error { return errors.New("failed") }
+
+func Test_Check(t *testing.T) {
+	ruleset := &RuleSet{Rules: []Rule{
+		&failedRule{name: "rule_a"},
+		&testRule{},
+		&failedRule{name: "rule_b"},
+	}}
+
+	err := ruleset.Check(&Client{})
+	expected := RuleErrors{
+		{Rule: "rule_a", Message: "failed"},
+		{Rule: "rule_b", Message: "failed"},
+	}
+	if !cmp.Equal(expected, err) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, err))
+	}
+	if err.Error() != "Failed to check `rule_a` rule: failed\nFailed to check `rule_b` rule: failed" {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+}
+
+func Test_Check_query(t *testing.T) {
+	ruleset := &RuleSet{Rules: []Rule{
+		&testRule{},
+		&queryRule{namedRule{name: "rule_a", enabled: true}},
+	}}
+	if err := ruleset.ApplyConfig(&Config{Rules: map[string]*RuleConfig{}, Query: true}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	err := ruleset.Check(&Client{})
+	expected := RuleErrors{{Rule: "rule_a", Message: "queried"}}
+	if !cmp.Equal(expected, err) {
+		t.Fatalf("Diff: %s", cmp.Diff(expected, err))
+	}
+}
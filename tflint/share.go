@@ -0,0 +1,94 @@
+package tflint
+
+import (
+	"reflect"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// Shared responses are served to multiple walks, including walks from other rules and concurrent walks.
+// To keep them safe, they are never modified after being stored, and each walk receives a copy of attributes and blocks.
+// Only the structs passed to walkers are copied, so rules can modify their fields (e.g. ranges) without affecting others.
+// Expressions and bodies are not copied because copying them is expensive. They must be treated as immutable.
+
+// decodeFilenames restores filenames compacted by the host in the passed response, and normalizes them relative to the module root.
+// Responses must be decoded before being shared, because decoding modifies ranges in place.
+func (c *Client) decodeFilenames(reply interface{}) {
+	switch resp := reply.(type) {
+	case *AttributesResponse:
+		resp.Filenames.Decode(&resp.Attributes)
+		resp.Filenames = nil
+		c.normalizeFilenames(&resp.Attributes)
+	case *DataSourceAttributesResponse:
+		c.normalizeFilenames(&resp.Attributes)
+	case *BlocksResponse:
+		resp.Filenames.Decode(&resp.Blocks)
+		resp.Filenames = nil
+		c.normalizeFilenames(&resp.Blocks)
+	case *AttributeValuesResponse:
+		c.normalizeFilenames(&resp.Attributes)
+	}
+}
+
+// normalizeFilenames normalizes filenames of ranges in the passed value. The value must be a pointer.
+func (c *Client) normalizeFilenames(v interface{}) {
+	root := c.moduleRoot()
+	walkRanges(reflect.ValueOf(v), map[uintptr]bool{}, func(filename string) string {
+		return normalizeFilename(root, filename)
+	})
+}
+
+// copyResponse returns a copy of the passed response that can be modified without affecting the original.
+// Responses that are not shared are returned as is.
+func copyResponse(reply interface{}) interface{} {
+	switch resp := reply.(type) {
+	case *AttributesResponse:
+		return &AttributesResponse{Attributes: copyAttributes(resp.Attributes), Err: resp.Err}
+	case *DataSourceAttributesResponse:
+		return &DataSourceAttributesResponse{Attributes: copyAttributes(resp.Attributes), Err: resp.Err}
+	case *BlocksResponse:
+		blocks := make([]*hcl.Block, len(resp.Blocks))
+		for i, block := range resp.Blocks {
+			blocks[i] = copyBlock(block)
+		}
+		return &BlocksResponse{Blocks: blocks, Err: resp.Err}
+	case *AttributeValuesResponse:
+		attributes := make([]*EvaluatedAttribute, len(resp.Attributes))
+		for i, attribute := range resp.Attributes {
+			copied := *attribute
+			copied.Attribute = copyAttribute(attribute.Attribute)
+			attributes[i] = &copied
+		}
+		return &AttributeValuesResponse{Attributes: attributes, Err: resp.Err}
+	default:
+		return reply
+	}
+}
+
+func copyAttributes(attributes []*hcl.Attribute) []*hcl.Attribute {
+	ret := make([]*hcl.Attribute, len(attributes))
+	for i, attribute := range attributes {
+		ret[i] = copyAttribute(attribute)
+	}
+	return ret
+}
+
+// copyAttribute returns a copy of the attribute. The expression is shared.
+func copyAttribute(attribute *hcl.Attribute) *hcl.Attribute {
+	if attribute == nil {
+		return nil
+	}
+	copied := *attribute
+	return &copied
+}
+
+// copyBlock returns a copy of the block, including labels and their ranges. The body is shared.
+func copyBlock(block *hcl.Block) *hcl.Block {
+	if block == nil {
+		return nil
+	}
+	copied := *block
+	copied.Labels = append([]string(nil), block.Labels...)
+	copied.LabelRanges = append([]hcl.Range(nil), block.LabelRanges...)
+	return &copied
+}
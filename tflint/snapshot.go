@@ -0,0 +1,108 @@
+package tflint
+
+import (
+	"encoding/json"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// SnapshotVersion is the version of the snapshot format.
+const SnapshotVersion = 1
+
+// Snapshot is a sanitized bundle of the values of variables and locals seen by the Runner.
+// It is intended to be attached to plugin bug reports so that maintainers can reproduce evaluation results
+// without access to the reporter's environment. Sensitive values are replaced with RedactedValue,
+// and values that cannot be evaluated (e.g. unknown) are null.
+type Snapshot struct {
+	Version   int                    `json:"version"`
+	Module    string                 `json:"module"`
+	Variables map[string]interface{} `json:"variables"`
+	Locals    map[string]interface{} `json:"locals"`
+}
+
+// TakeSnapshot evaluates all variables and locals in the module and returns the sanitized snapshot.
+// This is opt-in and makes one evaluation request per value, so call it only when debugging (e.g. behind a rule option).
+func TakeSnapshot(runner Runner) (*Snapshot, error) {
+	path, err := runner.ModulePath()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{
+		Version:   SnapshotVersion,
+		Module:    path.String(),
+		Variables: map[string]interface{}{},
+		Locals:    map[string]interface{}{},
+	}
+
+	err = runner.WalkVariables(func(variable *Variable) error {
+		// Evaluate a reference to the variable so that the value reflects variable files, etc.
+		expr, diags := hclsyntax.ParseExpression([]byte("var."+variable.Name), variable.DeclRange.Filename, variable.DeclRange.Start)
+		if diags.HasErrors() {
+			return diags
+		}
+		val, err := snapshotValue(runner, expr, variable.IsSensitive())
+		snapshot.Variables[variable.Name] = val
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := runner.GetModuleContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "locals"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range content.Blocks {
+		attributes, diags := block.Body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for name, attribute := range attributes {
+			val, err := snapshotValue(runner, attribute.Expr, false)
+			if err != nil {
+				return nil, err
+			}
+			snapshot.Locals[name] = val
+		}
+	}
+
+	return snapshot, nil
+}
+
+// JSON returns the JSON document of the snapshot. Keys are sorted, so the output is stable.
+func (s *Snapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// snapshotValue returns the sanitized value of the expression.
+// Evaluation errors result in null, but errors in communicating with the host are returned.
+func snapshotValue(runner Runner, expr hcl.Expression, sensitive bool) (interface{}, error) {
+	if !sensitive {
+		var err error
+		sensitive, err = runner.IsSensitive(expr)
+		if err != nil {
+			return nil, snapshotError(err)
+		}
+	}
+	if sensitive {
+		return RedactedValue, nil
+	}
+
+	val, err := evaluateToJSONCompatible(runner, expr)
+	if err != nil {
+		return nil, snapshotError(err)
+	}
+	return val, nil
+}
+
+func snapshotError(err error) error {
+	switch err.(type) {
+	case ProtocolError, CallBudgetError:
+		return err
+	default:
+		return nil
+	}
+}
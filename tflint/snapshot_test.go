@@ -0,0 +1,59 @@
+package tflint_test
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_TakeSnapshot(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{"main.tf": `
+variable "region" {
+  default = "us-east-1"
+}
+
+variable "password" {
+  default   = "secret"
+  sensitive = true
+}
+
+variable "token" {
+  default = "secret"
+}
+
+variable "computed" {}
+
+locals {
+  name = "web"
+  size = 2
+}`})
+	runner.SensitiveVariables = []string{"token"}
+
+	snapshot, err := tflint.TakeSnapshot(runner)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	got, err := snapshot.JSON()
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := `{
+  "version": 1,
+  "module": "root",
+  "variables": {
+    "computed": null,
+    "password": "***",
+    "region": "us-east-1",
+    "token": "***"
+  },
+  "locals": {
+    "name": "web",
+    "size": 2
+  }
+}`
+	if string(got) != expected {
+		t.Fatalf("Expected %s, but got %s", expected, got)
+	}
+}
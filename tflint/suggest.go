@@ -0,0 +1,69 @@
+package tflint
+
+import "fmt"
+
+// suggestionThreshold is the maximum edit distance for a candidate to be suggested.
+const suggestionThreshold = 3
+
+// Suggest returns the candidate closest to the passed value by Levenshtein distance.
+// If no candidate is close enough, it returns an empty string.
+func Suggest(value string, candidates []string) string {
+	best := ""
+	bestDistance := suggestionThreshold + 1
+
+	for _, candidate := range candidates {
+		if d := levenshtein(value, candidate); d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// DidYouMean returns a message fragment like ` Did you mean "t2.micro"?` for the closest candidate.
+// If no candidate is close enough, it returns an empty string, so it can always be appended to messages.
+//
+// Example:
+//
+//	fmt.Sprintf(`"%s" is an invalid instance type.%s`, instanceType, tflint.DidYouMean(instanceType, validTypes))
+func DidYouMean(value string, candidates []string) string {
+	suggestion := Suggest(value, candidates)
+	if suggestion == "" || suggestion == value {
+		return ""
+	}
+	return fmt.Sprintf(` Did you mean "%s"?`, suggestion)
+}
+
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(t)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,41 @@
+package tflint
+
+import "testing"
+
+func Test_DidYouMean(t *testing.T) {
+	candidates := []string{"t2.micro", "t2.small", "m5.large"}
+
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected string
+	}{
+		{
+			Name:     "typo",
+			Value:    "t2.micor",
+			Expected: ` Did you mean "t2.micro"?`,
+		},
+		{
+			Name:     "missing character",
+			Value:    "m5.larg",
+			Expected: ` Did you mean "m5.large"?`,
+		},
+		{
+			Name:     "exact match",
+			Value:    "t2.micro",
+			Expected: "",
+		},
+		{
+			Name:     "far away",
+			Value:    "c4.8xlarge",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		got := DidYouMean(tc.Value, candidates)
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected `%s`, but got `%s`", tc.Name, tc.Expected, got)
+		}
+	}
+}
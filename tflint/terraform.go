@@ -0,0 +1,60 @@
+package tflint
+
+import hcl "github.com/hashicorp/hcl/v2"
+
+// TerraformSettings is a `terraform` block declared in the module.
+type TerraformSettings struct {
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the terraform block.
+	Ranges BlockRanges
+
+	// RequiredVersion is the `required_version` attribute. It is nil if not declared.
+	RequiredVersion *hcl.Attribute
+	// RequiredProviders is the set of attributes in `required_providers` blocks keyed by the local name of providers.
+	RequiredProviders map[string]*hcl.Attribute
+	// Backend is the `backend` block. It is nil if not declared. If declared multiple times, the first one is set.
+	Backend *hcl.Block
+}
+
+// terraformSettingsSchema is the schema of terraform blocks
+var terraformSettingsSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "required_version"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "required_providers"},
+		{Type: "backend", LabelNames: []string{"type"}},
+	},
+}
+
+// NewTerraformSettings extracts the settings from the passed terraform block.
+func NewTerraformSettings(terraform *hcl.Block) (*TerraformSettings, hcl.Diagnostics) {
+	content, _, diags := terraform.Body.PartialContent(terraformSettingsSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	settings := &TerraformSettings{
+		DeclRange:         terraform.DefRange,
+		Ranges:            NewBlockRanges(terraform),
+		RequiredVersion:   content.Attributes["required_version"],
+		RequiredProviders: map[string]*hcl.Attribute{},
+	}
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "required_providers":
+			attributes, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			for name, attribute := range attributes {
+				settings.RequiredProviders[name] = attribute
+			}
+		case "backend":
+			if settings.Backend == nil {
+				settings.Backend = block
+			}
+		}
+	}
+	return settings, nil
+}
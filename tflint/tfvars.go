@@ -0,0 +1,42 @@
+package tflint
+
+import (
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// VariableFile is a variable definitions file (*.tfvars, *.tfvars.json).
+type VariableFile struct {
+	Filename string
+	// Assignments is a list of variable assignments in declaration order.
+	Assignments []*hcl.Attribute
+}
+
+// Assignment returns the assignment of the passed variable, or nil if the variable is not assigned.
+func (f *VariableFile) Assignment(name string) *hcl.Attribute {
+	for _, assignment := range f.Assignments {
+		if assignment.Name == name {
+			return assignment
+		}
+	}
+	return nil
+}
+
+// NewVariableFile extracts variable assignments from the passed file.
+func NewVariableFile(filename string, file *hcl.File) (*VariableFile, hcl.Diagnostics) {
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	assignments := make([]*hcl.Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		assignments = append(assignments, attribute)
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return assignments[i].Range.Start.Byte < assignments[j].Range.Start.Byte
+	})
+
+	return &VariableFile{Filename: filename, Assignments: assignments}, nil
+}
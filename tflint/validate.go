@@ -0,0 +1,74 @@
+package tflint
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Validate checks the ruleset for common authoring mistakes.
+// It verifies that the ruleset has a name and version, and that every rule is non-nil,
+// has a unique snake_case name, a valid severity, and a well-formed link. Custom function names must also be snake_case.
+// All problems found are reported together.
+func (r *RuleSet) Validate() error {
+	problems := []string{}
+
+	if r.Name == "" {
+		problems = append(problems, "ruleset name is empty")
+	}
+	if r.Version == "" {
+		problems = append(problems, "ruleset version is empty")
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range r.Rules {
+		if rule == nil {
+			problems = append(problems, fmt.Sprintf("rule #%d is nil", i))
+			continue
+		}
+
+		name := rule.Name()
+		switch {
+		case name == "":
+			problems = append(problems, fmt.Sprintf("rule #%d has an empty name", i))
+		case !ruleNamePattern.MatchString(name):
+			problems = append(problems, fmt.Sprintf("`%s` rule name must consist of lowercase letters, digits and underscores", name))
+		case seen[name]:
+			problems = append(problems, fmt.Sprintf("`%s` rule is defined more than once", name))
+		}
+		seen[name] = true
+
+		switch rule.Severity() {
+		case ERROR, WARNING, NOTICE:
+		default:
+			problems = append(problems, fmt.Sprintf("`%s` rule has an invalid severity `%s`", name, rule.Severity()))
+		}
+
+		if link := r.RuleLink(rule); link != "" {
+			u, err := url.Parse(link)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Contains(link, "{{") {
+				problems = append(problems, fmt.Sprintf("`%s` rule has an invalid link `%s`", name, link))
+			}
+		}
+	}
+
+	functions := []string{}
+	for name := range r.Functions {
+		functions = append(functions, name)
+	}
+	sort.Strings(functions)
+	for _, name := range functions {
+		if !ruleNamePattern.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("`%s` function name must consist of lowercase letters, digits and underscores", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid ruleset:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
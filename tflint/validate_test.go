@@ -0,0 +1,75 @@
+package tflint
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+type invalidRule struct {
+	testRule
+	name     string
+	severity string
+	link     string
+}
+
+func (r *invalidRule) Name() string     { return r.name }
+func (r *invalidRule) Severity() string { return r.severity }
+func (r *invalidRule) Link() string     { return r.link }
+
+func Test_Validate(t *testing.T) {
+	cases := []struct {
+		Name     string
+		RuleSet  *RuleSet
+		Expected string
+	}{
+		{
+			Name: "valid",
+			RuleSet: &RuleSet{
+				Name:    "test",
+				Version: "0.1.0",
+				DocsURL: "https://example.com/{{slug}}.md",
+				Rules:   []Rule{&testRule{}},
+			},
+		},
+		{
+			Name: "invalid",
+			RuleSet: &RuleSet{
+				Rules: []Rule{
+					&testRule{},
+					&testRule{},
+					nil,
+					&invalidRule{name: "Invalid-Name", severity: ERROR},
+					&invalidRule{name: "bad_severity", severity: "Critical"},
+					&invalidRule{name: "bad_link", severity: NOTICE, link: "docs/bad_link.md"},
+				},
+				Functions: map[string]function.Function{
+					"valid_function":  function.New(&function.Spec{}),
+					"invalidFunction": function.New(&function.Spec{}),
+				},
+			},
+			Expected: `Invalid ruleset:
+  - ruleset name is empty
+  - ruleset version is empty
+  - ` + "`test`" + ` rule is defined more than once
+  - rule #2 is nil
+  - ` + "`Invalid-Name`" + ` rule name must consist of lowercase letters, digits and underscores
+  - ` + "`bad_severity`" + ` rule has an invalid severity ` + "`Critical`" + `
+  - ` + "`bad_link`" + ` rule has an invalid link ` + "`docs/bad_link.md`" + `
+  - ` + "`invalidFunction`" + ` function name must consist of lowercase letters, digits and underscores`,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.RuleSet.Validate()
+		if err == nil {
+			if tc.Expected != "" {
+				t.Fatalf("Failed `%s` test: expected error is not occurred `%s`", tc.Name, tc.Expected)
+			}
+			continue
+		}
+		if err.Error() != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected error is %s, but get %s", tc.Name, tc.Expected, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tflint
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
+)
+
+// Numbers are transferred as cty.Value, which is encoded by gob as an arbitrary-precision *big.Float.
+// This means that large numbers such as account IDs and 64-bit quotas never pass through float64.
+// The following helpers convert such values into fixed-size integers without losing precision.
+
+// Int64FromValue converts the passed number (or numeric string) value into int64.
+// It returns a TypeConversionError if the value is not a whole number or overflows int64.
+func Int64FromValue(val cty.Value) (int64, error) {
+	i, err := bigIntFromValue(val)
+	if err != nil {
+		return 0, err
+	}
+	if !i.IsInt64() {
+		return 0, Error{
+			Code:    TypeConversionError,
+			Level:   ErrorLevel,
+			Message: fmt.Sprintf("%s overflows int64", i),
+		}
+	}
+	return i.Int64(), nil
+}
+
+// Uint64FromValue converts the passed number (or numeric string) value into uint64.
+// It returns a TypeConversionError if the value is not a whole number or overflows uint64.
+func Uint64FromValue(val cty.Value) (uint64, error) {
+	i, err := bigIntFromValue(val)
+	if err != nil {
+		return 0, err
+	}
+	if !i.IsUint64() {
+		return 0, Error{
+			Code:    TypeConversionError,
+			Level:   ErrorLevel,
+			Message: fmt.Sprintf("%s overflows uint64", i),
+		}
+	}
+	return i.Uint64(), nil
+}
+
+func bigIntFromValue(val cty.Value) (*big.Int, error) {
+	if !val.IsKnown() {
+		return nil, Error{
+			Code:    UnknownValueError,
+			Level:   WarningLevel,
+			Message: "Unknown value found",
+		}
+	}
+	if val.IsNull() {
+		return nil, Error{
+			Code:    NullValueError,
+			Level:   WarningLevel,
+			Message: "Null value found",
+		}
+	}
+
+	num, err := convert.Convert(val, cty.Number)
+	if err != nil {
+		return nil, Error{
+			Code:    TypeConversionError,
+			Level:   ErrorLevel,
+			Message: "Failed to convert the value into a number",
+			Cause:   err,
+		}
+	}
+
+	i, accuracy := num.AsBigFloat().Int(nil)
+	if accuracy != big.Exact {
+		return nil, Error{
+			Code:    TypeConversionError,
+			Level:   ErrorLevel,
+			Message: fmt.Sprintf("%s is not a whole number", num.AsBigFloat().Text('f', -1)),
+		}
+	}
+	return i, nil
+}
+
+// MarshalValue encodes the passed value in MessagePack.
+// gob cannot encode unknown values, so values that are not wholly known are sent in this form instead.
+func MarshalValue(val cty.Value) ([]byte, error) {
+	return ctymsgpack.Marshal(val, cty.DynamicPseudoType)
+}
+
+// UnmarshalValue decodes the value encoded by MarshalValue.
+func UnmarshalValue(src []byte) (cty.Value, error) {
+	return ctymsgpack.Unmarshal(src, cty.DynamicPseudoType)
+}
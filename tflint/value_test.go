@@ -0,0 +1,129 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func Test_ValueTransferPrecision(t *testing.T) {
+	cases := []struct {
+		Name string
+		Val  cty.Value
+	}{
+		{
+			Name: "max int64",
+			Val:  cty.NumberIntVal(math.MaxInt64),
+		},
+		{
+			Name: "max uint64",
+			Val:  cty.NumberUIntVal(math.MaxUint64),
+		},
+		{
+			Name: "account ID",
+			Val:  cty.MustParseNumberVal("123456789012"),
+		},
+		{
+			Name: "beyond uint64",
+			Val:  cty.MustParseNumberVal("123456789012345678901234567890"),
+		},
+	}
+
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(EvalExprResponse{Val: tc.Val}); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+		var resp EvalExprResponse
+		if err := gob.NewDecoder(&buf).Decode(&resp); err != nil {
+			t.Fatalf("Failed `%s` test: %s", tc.Name, err)
+		}
+
+		if !resp.Val.RawEquals(tc.Val) {
+			t.Fatalf("Failed `%s` test: expected %#v, but got %#v", tc.Name, tc.Val, resp.Val)
+		}
+	}
+}
+
+func Test_Int64FromValue(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Val      cty.Value
+		Expected int64
+		Error    string
+	}{
+		{
+			Name:     "max int64",
+			Val:      cty.NumberIntVal(math.MaxInt64),
+			Expected: math.MaxInt64,
+		},
+		{
+			Name:     "numeric string",
+			Val:      cty.StringVal("123456789012"),
+			Expected: 123456789012,
+		},
+		{
+			Name:  "overflow",
+			Val:   cty.NumberUIntVal(math.MaxUint64),
+			Error: "18446744073709551615 overflows int64",
+		},
+		{
+			Name:  "fraction",
+			Val:   cty.NumberFloatVal(1.5),
+			Error: "1.5 is not a whole number",
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := Int64FromValue(tc.Val)
+		if err != nil {
+			if err.Error() != tc.Error {
+				t.Fatalf("Failed `%s` test: expected error is `%s`, but got `%s`", tc.Name, tc.Error, err)
+			}
+			continue
+		}
+		if tc.Error != "" {
+			t.Fatalf("Failed `%s` test: expected error is not occurred `%s`", tc.Name, tc.Error)
+		}
+		if got != tc.Expected {
+			t.Fatalf("Failed `%s` test: expected %d, but got %d", tc.Name, tc.Expected, got)
+		}
+	}
+}
+
+func Test_Uint64FromValue(t *testing.T) {
+	got, err := Uint64FromValue(cty.NumberUIntVal(math.MaxUint64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != math.MaxUint64 {
+		t.Fatalf("Expected %d, but got %d", uint64(math.MaxUint64), got)
+	}
+
+	if _, err := Uint64FromValue(cty.NumberIntVal(-1)); err == nil {
+		t.Fatal("Expected an overflow error, but no error occurred")
+	}
+}
+
+func Test_MarshalValue(t *testing.T) {
+	val := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("web"),
+		"env":  cty.UnknownVal(cty.String),
+		"tags": cty.ListVal([]cty.Value{cty.UnknownVal(cty.Number)}),
+	})
+
+	src, err := MarshalValue(val)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalValue(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.RawEquals(val) {
+		t.Fatalf("Expected %#v, but got %#v", val, got)
+	}
+}
@@ -0,0 +1,89 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Variable is a `variable` block declared in the module.
+// Each attribute is nil if not declared.
+type Variable struct {
+	Name      string
+	DeclRange hcl.Range
+	// Ranges is the set of ranges of the variable block.
+	Ranges BlockRanges
+
+	// Type is the type constraint. Its expression is a type expression, not a value.
+	Type        *hcl.Attribute
+	Default     *hcl.Attribute
+	Description *hcl.Attribute
+	Sensitive   *hcl.Attribute
+}
+
+// variableSchema is the schema of variable blocks
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"},
+		{Name: "default"},
+		{Name: "description"},
+		{Name: "sensitive"},
+	},
+}
+
+// NewVariable extracts the variable declaration from the passed variable block.
+func NewVariable(variable *hcl.Block) (*Variable, hcl.Diagnostics) {
+	content, _, diags := variable.Body.PartialContent(variableSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &Variable{
+		Name:        variable.Labels[0],
+		DeclRange:   variable.DefRange,
+		Ranges:      NewBlockRanges(variable),
+		Type:        content.Attributes["type"],
+		Default:     content.Attributes["default"],
+		Description: content.Attributes["description"],
+		Sensitive:   content.Attributes["sensitive"],
+	}, nil
+}
+
+// DescriptionText returns the description. An empty string is returned if it is not declared or not a static string.
+func (v *Variable) DescriptionText() string {
+	return staticString(v.Description)
+}
+
+// IsSensitive returns true if the variable is declared as sensitive.
+func (v *Variable) IsSensitive() bool {
+	return staticBool(v.Sensitive)
+}
+
+// staticString returns the value of the attribute if it is a static string, or an empty string.
+func staticString(attribute *hcl.Attribute) string {
+	val := staticValue(attribute)
+	if val.Type() != cty.String {
+		return ""
+	}
+	return val.AsString()
+}
+
+// staticBool returns the value of the attribute if it is a static bool, or false.
+func staticBool(attribute *hcl.Attribute) bool {
+	val := staticValue(attribute)
+	if val.Type() != cty.Bool {
+		return false
+	}
+	return val.True()
+}
+
+// staticValue returns the value of the attribute if it is known without any context, or cty.DynamicVal.
+func staticValue(attribute *hcl.Attribute) cty.Value {
+	if attribute == nil {
+		return cty.DynamicVal
+	}
+	val, diags := attribute.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() {
+		return cty.DynamicVal
+	}
+	return val
+}
@@ -0,0 +1,95 @@
+package tflint
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ExprVisitor is a set of callbacks called for each kind of expression by VisitExpr.
+// Callbacks that are nil are skipped. Each callback receives the ancestors of the expression,
+// with the outermost first, so that structural rules (e.g. "no nested conditionals") can inspect the context.
+//
+// Returning an error from a callback stops the visit, and VisitExpr returns the error.
+type ExprVisitor struct {
+	// Expr is called for every expression before the specific callbacks.
+	Expr         func(expr hclsyntax.Expression, parents []hclsyntax.Expression) error
+	FunctionCall func(expr *hclsyntax.FunctionCallExpr, parents []hclsyntax.Expression) error
+	Conditional  func(expr *hclsyntax.ConditionalExpr, parents []hclsyntax.Expression) error
+	Traversal    func(expr *hclsyntax.ScopeTraversalExpr, parents []hclsyntax.Expression) error
+	Literal      func(expr *hclsyntax.LiteralValueExpr, parents []hclsyntax.Expression) error
+	Template     func(expr *hclsyntax.TemplateExpr, parents []hclsyntax.Expression) error
+}
+
+// VisitExpr visits the passed expression and its descendants in depth-first order.
+// Only expressions in native syntax are supported. Other expressions (e.g. JSON syntax) are ignored.
+func VisitExpr(expr hcl.Expression, visitor *ExprVisitor) error {
+	native, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return nil
+	}
+
+	walker := &exprWalker{visitor: visitor, parents: []hclsyntax.Expression{}}
+	hclsyntax.Walk(native, walker)
+	return walker.err
+}
+
+type exprWalker struct {
+	visitor *ExprVisitor
+	parents []hclsyntax.Expression
+	err     error
+}
+
+func (w *exprWalker) Enter(node hclsyntax.Node) hcl.Diagnostics {
+	expr, ok := node.(hclsyntax.Expression)
+	if !ok {
+		return nil
+	}
+	if w.err == nil {
+		w.err = w.visit(expr)
+	}
+	w.parents = append(w.parents, expr)
+	return nil
+}
+
+func (w *exprWalker) Exit(node hclsyntax.Node) hcl.Diagnostics {
+	if _, ok := node.(hclsyntax.Expression); ok {
+		w.parents = w.parents[:len(w.parents)-1]
+	}
+	return nil
+}
+
+func (w *exprWalker) visit(expr hclsyntax.Expression) error {
+	// Pass a copy so that callbacks can retain parents safely.
+	parents := make([]hclsyntax.Expression, len(w.parents))
+	copy(parents, w.parents)
+
+	if w.visitor.Expr != nil {
+		if err := w.visitor.Expr(expr, parents); err != nil {
+			return err
+		}
+	}
+
+	switch expr := expr.(type) {
+	case *hclsyntax.FunctionCallExpr:
+		if w.visitor.FunctionCall != nil {
+			return w.visitor.FunctionCall(expr, parents)
+		}
+	case *hclsyntax.ConditionalExpr:
+		if w.visitor.Conditional != nil {
+			return w.visitor.Conditional(expr, parents)
+		}
+	case *hclsyntax.ScopeTraversalExpr:
+		if w.visitor.Traversal != nil {
+			return w.visitor.Traversal(expr, parents)
+		}
+	case *hclsyntax.LiteralValueExpr:
+		if w.visitor.Literal != nil {
+			return w.visitor.Literal(expr, parents)
+		}
+	case *hclsyntax.TemplateExpr:
+		if w.visitor.Template != nil {
+			return w.visitor.Template(expr, parents)
+		}
+	}
+	return nil
+}
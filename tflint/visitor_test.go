@@ -0,0 +1,65 @@
+package tflint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_VisitExpr(t *testing.T) {
+	expr, diags := hclsyntax.ParseExpression([]byte(`var.a ? (var.b ? "x" : "y") : jsonencode(var.secret)`), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	nested := 0
+	functions := []string{}
+	traversals := []string{}
+	err := VisitExpr(expr, &ExprVisitor{
+		Conditional: func(expr *hclsyntax.ConditionalExpr, parents []hclsyntax.Expression) error {
+			for _, parent := range parents {
+				if _, ok := parent.(*hclsyntax.ConditionalExpr); ok {
+					nested++
+					break
+				}
+			}
+			return nil
+		},
+		FunctionCall: func(expr *hclsyntax.FunctionCallExpr, parents []hclsyntax.Expression) error {
+			functions = append(functions, expr.Name)
+			return nil
+		},
+		Traversal: func(expr *hclsyntax.ScopeTraversalExpr, parents []hclsyntax.Expression) error {
+			traversals = append(traversals, expr.Traversal[1].(hcl.TraverseAttr).Name)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if nested != 1 {
+		t.Fatalf("Expected 1 nested conditional, but got %d", nested)
+	}
+	if !cmp.Equal(functions, []string{"jsonencode"}) {
+		t.Fatalf("Unexpected functions: %#v", functions)
+	}
+	if !cmp.Equal(traversals, []string{"a", "b", "secret"}) {
+		t.Fatalf("Unexpected traversals: %#v", traversals)
+	}
+
+	// The first error stops the visit
+	visited := 0
+	err = VisitExpr(expr, &ExprVisitor{
+		Traversal: func(expr *hclsyntax.ScopeTraversalExpr, parents []hclsyntax.Expression) error {
+			visited++
+			return errors.New("stop")
+		},
+	})
+	if err == nil || err.Error() != "stop" || visited != 1 {
+		t.Fatalf("Expected the visit to stop at the first error, but got err=%v, visited=%d", err, visited)
+	}
+}
@@ -0,0 +1,80 @@
+package tflint
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// wireTypes is the list of concrete types sent via RPC as interface values (e.g. error, hcl.Expression, hcl.Body).
+// gob can decode interface values only if their concrete types are registered in both processes.
+var wireTypes = []interface{}{
+	Error{},
+	RuleErrors{},
+	// Expressions that cannot be encoded as they are (e.g. JSON syntax) are sent as a wire representation
+	&WireExpr{},
+	// https://github.com/hashicorp/hcl/blob/v2.0.0/hclsyntax/structure.go
+	&hclsyntax.Body{},
+	// https://github.com/hashicorp/hcl/blob/v2.0.0/hclsyntax/expression.go
+	&hclsyntax.LiteralValueExpr{},
+	&hclsyntax.ScopeTraversalExpr{},
+	&hclsyntax.RelativeTraversalExpr{},
+	&hclsyntax.FunctionCallExpr{},
+	&hclsyntax.ConditionalExpr{},
+	&hclsyntax.IndexExpr{},
+	&hclsyntax.TupleConsExpr{},
+	&hclsyntax.ObjectConsExpr{},
+	&hclsyntax.ObjectConsKeyExpr{},
+	&hclsyntax.ForExpr{},
+	&hclsyntax.SplatExpr{},
+	&hclsyntax.AnonSymbolExpr{},
+	// https://github.com/hashicorp/hcl/blob/v2.0.0/hclsyntax/expression_ops.go
+	&hclsyntax.BinaryOpExpr{},
+	&hclsyntax.UnaryOpExpr{},
+	// https://github.com/hashicorp/hcl/blob/v2.0.0/hclsyntax/expression_template.go
+	&hclsyntax.TemplateExpr{},
+	&hclsyntax.TemplateJoinExpr{},
+	&hclsyntax.TemplateWrapExpr{},
+	// https://github.com/hashicorp/hcl/blob/v2.0.0/traversal.go
+	hcl.TraverseRoot{},
+	hcl.TraverseAttr{},
+	hcl.TraverseIndex{},
+	hcl.TraverseSplat{},
+}
+
+var registerWireTypesOnce sync.Once
+
+// RegisterWireTypes registers the types sent via RPC as interface values in encoding/gob.
+// The Client and plugin servers call it explicitly, so registration does not depend on which packages
+// are imported or on their initialization order. It is safe to call multiple times.
+func RegisterWireTypes() {
+	registerWireTypesOnce.Do(func() {
+		for _, value := range wireTypes {
+			gob.Register(value)
+		}
+	})
+}
+
+// CheckWireTypes returns an error listing the wire types that cannot be encoded as interface values.
+// Plugins call it before serving, so that missing registrations fail fast with a clear message
+// instead of "gob: name not registered" in the middle of inspection.
+func CheckWireTypes() error {
+	missing := []string{}
+	for _, value := range wireTypes {
+		wrapper := struct{ Value interface{} }{Value: value}
+		err := gob.NewEncoder(ioutil.Discard).Encode(&wrapper)
+		if err != nil && strings.Contains(err.Error(), "not registered") {
+			missing = append(missing, fmt.Sprintf("%T", value))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Failed to register types sent via RPC: %s. Call tflint.RegisterWireTypes before serving", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package tflint
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_CheckWireTypes(t *testing.T) {
+	RegisterWireTypes()
+	// Calling it again must not panic
+	RegisterWireTypes()
+
+	if err := CheckWireTypes(); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
+
+func Test_wireTypes_coverage(t *testing.T) {
+	RegisterWireTypes()
+
+	src := []byte(`value = {
+  literal     = 1
+  traversal   = var.list[0].name
+  relative    = func()[0]
+  conditional = var.enabled ? -1 : 2 + 3
+  index       = var.map[local.key]
+  tuple       = [for k, v in var.map : upper(v) if k != ""]
+  object      = { for k, v in var.map : k => v... }
+  splat       = var.list[*].id
+  template    = "${var.name}-%{ for v in var.list }${v}%{ endfor }"
+  wrap        = "${var.name}"
+}`)
+	file, diags := hclsyntax.ParseConfig(src, "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	registered := map[reflect.Type]bool{}
+	for _, value := range wireTypes {
+		registered[reflect.TypeOf(value)] = true
+	}
+
+	body := file.Body.(*hclsyntax.Body)
+	diags = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		if _, ok := node.(hclsyntax.Expression); ok && !registered[reflect.TypeOf(node)] {
+			t.Errorf("%T is not a wire type", node)
+		}
+		return nil
+	})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	// Operators (e.g. BinaryOpExpr) have function implementations, which gob cannot encode,
+	// so only an expression without operators is encoded here.
+	items := body.Attributes["value"].Expr.(*hclsyntax.ObjectConsExpr).Items
+	wrapper := struct{ Expr hcl.Expression }{Expr: items[len(items)-2].ValueExpr}
+	if err := gob.NewEncoder(&bytes.Buffer{}).Encode(&wrapper); err != nil {
+		t.Fatalf("Failed to encode the expression: %s", err)
+	}
+}